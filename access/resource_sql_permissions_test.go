@@ -178,7 +178,7 @@ var createHighConcurrencyCluster = []qa.HTTPFixture{
 	{
 		Method:       "GET",
 		ReuseRequest: true,
-		Resource:     "/api/2.0/clusters/list",
+		Resource:     "/api/2.1/clusters/list",
 		Response:     map[string]any{},
 	},
 	{
@@ -255,7 +255,7 @@ var createSharedCluster = []qa.HTTPFixture{
 	{
 		Method:       "GET",
 		ReuseRequest: true,
-		Resource:     "/api/2.0/clusters/list",
+		Resource:     "/api/2.1/clusters/list",
 		Response:     map[string]any{},
 	},
 	{
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -48,6 +49,25 @@ func (a InstanceProfilesAPI) Create(ipi InstanceProfileInfo) error {
 	return a.client.Post(a.context, "/instance-profiles/add", ipi, nil)
 }
 
+// AddIfNotExists registers the instance profile, doing nothing if it's already registered
+func (a InstanceProfilesAPI) AddIfNotExists(arn string, skipValidation bool) error {
+	_, err := a.Read(arn)
+	if err == nil {
+		return nil
+	}
+	if !apierr.IsMissing(err) {
+		return err
+	}
+	err = a.Create(InstanceProfileInfo{
+		InstanceProfileArn: arn,
+		SkipValidation:     skipValidation,
+	})
+	if errors.Is(err, apierr.ErrResourceAlreadyExists) {
+		return nil
+	}
+	return err
+}
+
 // Read returns the ARN back if it exists on the Databricks workspace
 func (a InstanceProfilesAPI) Read(instanceProfileARN string) (result InstanceProfileInfo, err error) {
 	instanceProfiles, err := a.List()
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databricks/terraform-provider-databricks/common"
@@ -374,3 +375,34 @@ func TestResourceInstanceProfileUpdate_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile", d.Id())
 }
+
+func TestInstanceProfilesAddIfNotExists(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/instance-profiles/list",
+			ReuseRequest: true,
+			Response: InstanceProfileList{
+				InstanceProfiles: []InstanceProfileInfo{
+					{
+						InstanceProfileArn: "arn:aws:iam::999999999999:instance-profile/existing",
+					},
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/instance-profiles/add",
+			ExpectedRequest: InstanceProfileInfo{
+				InstanceProfileArn: "arn:aws:iam::999999999999:instance-profile/new",
+				SkipValidation:     true,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewInstanceProfilesAPI(ctx, client)
+		err := a.AddIfNotExists("arn:aws:iam::999999999999:instance-profile/existing", false)
+		assert.NoError(t, err)
+		err = a.AddIfNotExists("arn:aws:iam::999999999999:instance-profile/new", true)
+		assert.NoError(t, err)
+	})
+}
@@ -2,14 +2,48 @@ package catalog
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/catalog"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// GetMetastoreAssignment returns the metastore ID and default catalog name assigned to the workspace. On workspace
+// level only the assignment of the current workspace is available, which is also returned for zero workspace ID.
+// Workspaces without an assignment return a not found error.
+func GetMetastoreAssignment(ctx context.Context, c *common.DatabricksClient,
+	workspaceId int64) (ma catalog.MetastoreAssignment, err error) {
+	err = c.AccountOrWorkspaceRequest(func(acc *databricks.AccountClient) error {
+		assignment, err := acc.MetastoreAssignments.GetByWorkspaceId(ctx, workspaceId)
+		if err != nil {
+			return err
+		}
+		if assignment.MetastoreAssignment != nil {
+			ma = *assignment.MetastoreAssignment
+		}
+		return nil
+	}, func(w *databricks.WorkspaceClient) error {
+		assignment, err := w.Metastores.Current(ctx)
+		if err != nil {
+			return err
+		}
+		if workspaceId != 0 && assignment.WorkspaceId != workspaceId {
+			return fmt.Errorf("cannot get metastore assignment of workspace %d: only the assignment "+
+				"of the current workspace %d is available on workspace level", workspaceId, assignment.WorkspaceId)
+		}
+		ma = *assignment
+		return nil
+	})
+	if apierr.IsMissing(err) || (err == nil && ma.MetastoreId == "") {
+		return ma, apierr.NotFound(fmt.Sprintf("workspace %d has no metastore assigned", workspaceId))
+	}
+	return ma, err
+}
+
 func ResourceMetastoreAssignment() common.Resource {
 	s := common.StructToSchema(catalog.MetastoreAssignment{},
 		func(m map[string]*schema.Schema) map[string]*schema.Schema {
@@ -1,10 +1,15 @@
 package catalog
 
 import (
+	"context"
 	"testing"
 
+	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMetastoreAssignmentCornerCases(t *testing.T) {
@@ -182,3 +187,71 @@ func TestMetastoreAssignmentWorskpace_Update(t *testing.T) {
 		`,
 	}.ApplyNoError(t)
 }
+
+func TestGetMetastoreAssignment(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/100/workspaces/123/metastore?",
+			Response: `{
+				"metastore_assignment": {
+					"workspace_id": 123,
+					"metastore_id": "a",
+					"default_catalog_name": "main"
+				}
+			}`,
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/100/workspaces/124/metastore?",
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Workspace 124 has no metastore assignment",
+			},
+			Status: 404,
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	client.Config.WithTesting().AccountID = "100"
+	ctx := context.Background()
+
+	ma, err := GetMetastoreAssignment(ctx, client, 123)
+	require.NoError(t, err)
+	assert.Equal(t, "a", ma.MetastoreId)
+	assert.Equal(t, "main", ma.DefaultCatalogName)
+
+	_, err = GetMetastoreAssignment(ctx, client, 124)
+	assert.True(t, apierr.IsMissing(err))
+	assert.EqualError(t, err, "workspace 124 has no metastore assigned")
+}
+
+func TestGetMetastoreAssignment_Workspace(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.1/unity-catalog/current-metastore-assignment",
+			ReuseRequest: true,
+			Response: catalog.MetastoreAssignment{
+				WorkspaceId:        123,
+				MetastoreId:        "a",
+				DefaultCatalogName: "main",
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	ctx := context.Background()
+
+	ma, err := GetMetastoreAssignment(ctx, client, 123)
+	require.NoError(t, err)
+	assert.Equal(t, "main", ma.DefaultCatalogName)
+
+	ma, err = GetMetastoreAssignment(ctx, client, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "a", ma.MetastoreId)
+
+	_, err = GetMetastoreAssignment(ctx, client, 124)
+	assert.EqualError(t, err, "cannot get metastore assignment of workspace 124: only the assignment "+
+		"of the current workspace 123 is available on workspace level")
+}
@@ -1595,7 +1595,7 @@ var baseClusterFixture = []qa.HTTPFixture{
 var useExistingClusterForSql = append([]qa.HTTPFixture{
 	{
 		Method:   "GET",
-		Resource: "/api/2.0/clusters/list",
+		Resource: "/api/2.1/clusters/list",
 		Response: clusters.ClusterList{
 			Clusters: []clusters.ClusterInfo{
 				{
@@ -1616,7 +1616,7 @@ var createClusterForSql = append([]qa.HTTPFixture{
 	{
 		Method:       "GET",
 		ReuseRequest: true,
-		Resource:     "/api/2.0/clusters/list",
+		Resource:     "/api/2.1/clusters/list",
 		Response:     map[string]any{},
 	},
 	{
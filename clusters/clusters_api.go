@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/compute"
 
+	"github.com/databricks/terraform-provider-databricks/aws"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -352,6 +355,8 @@ type EventDetails struct {
 	ResizeCause         *ResizeCause       `json:"cause,omitempty"`
 	Reason              *TerminationReason `json:"reason,omitempty"`
 	User                string             `json:"user"`
+
+	InitScripts *compute.InitScriptEventDetails `json:"init_scripts,omitempty"`
 }
 
 // ClusterEvent - event information
@@ -371,6 +376,30 @@ type EventsResponse struct {
 	TotalCount int64          `json:"total_count"`
 }
 
+// AutoscaleEvent is a single change in the number of cluster workers
+type AutoscaleEvent struct {
+	Timestamp   int64        `json:"timestamp"`
+	Direction   string       `json:"direction"`
+	FromWorkers int32        `json:"from_workers"`
+	ToWorkers   int32        `json:"to_workers"`
+	Cause       *ResizeCause `json:"cause,omitempty"`
+}
+
+// Directions of AutoscaleEvent
+const (
+	AutoscaleUpsize   = "UPSIZE"
+	AutoscaleDownsize = "DOWNSIZE"
+)
+
+// TerminationEvent is a single termination of the cluster together with its reason
+type TerminationEvent struct {
+	Timestamp  int64             `json:"timestamp"`
+	Code       string            `json:"code,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	User       string            `json:"user,omitempty"`
+}
+
 type WorkloadTypeClients struct {
 	Notebooks bool `json:"notebooks" tf:"optional,default:true"`
 	Jobs      bool `json:"jobs" tf:"optional,default:true"`
@@ -438,7 +467,10 @@ type Cluster struct {
 // They would then be using Validate(cluster compute.CreateCluster) defined in resource_cluster.go that is a duplicate of this method but uses Go SDK.
 func (cluster Cluster) Validate() error {
 	// TODO: rewrite with CustomizeDiff
-	if cluster.NumWorkers > 0 || cluster.Autoscale != nil {
+	if cluster.Autoscale != nil {
+		return validateAutoscale(int(cluster.Autoscale.MinWorkers), int(cluster.Autoscale.MaxWorkers))
+	}
+	if cluster.NumWorkers > 0 {
 		return nil
 	}
 	profile := cluster.SparkConf["spark.databricks.cluster.profile"]
@@ -450,6 +482,46 @@ func (cluster Cluster) Validate() error {
 	return fmt.Errorf("NumWorkers could be 0 only for SingleNode clusters. See https://docs.databricks.com/clusters/single-node.html for more details")
 }
 
+// validateAutoscale rejects autoscale bounds that the API refuses with a less descriptive error
+func validateAutoscale(minWorkers, maxWorkers int) error {
+	if maxWorkers <= 0 {
+		return fmt.Errorf("autoscale max_workers must be greater than 0, got %d", maxWorkers)
+	}
+	if minWorkers > maxWorkers {
+		return fmt.Errorf("autoscale min_workers (%d) must not be greater than max_workers (%d)",
+			minWorkers, maxWorkers)
+	}
+	return nil
+}
+
+// ValidateUnityCatalog checks for known-invalid combinations of runtime_engine, data_security_mode
+// and single_user_name, that would otherwise fail only when the cluster is created.
+func (cluster Cluster) ValidateUnityCatalog() error {
+	switch cluster.RuntimeEngine {
+	case "", "STANDARD", "PHOTON":
+	default:
+		return fmt.Errorf("runtime_engine must be STANDARD or PHOTON, got %s", cluster.RuntimeEngine)
+	}
+	switch cluster.DataSecurityMode {
+	case "", "LEGACY_SINGLE_USER", "LEGACY_SINGLE_USER_STANDARD":
+	case "NONE", "LEGACY_TABLE_ACL", "LEGACY_PASSTHROUGH", "USER_ISOLATION":
+		if cluster.SingleUserName != "" {
+			return fmt.Errorf("single_user_name can only be used with SINGLE_USER data_security_mode, got %s",
+				cluster.DataSecurityMode)
+		}
+	case "SINGLE_USER":
+		if cluster.SingleUserName == "" {
+			return fmt.Errorf("single_user_name is required for SINGLE_USER data_security_mode")
+		}
+	default:
+		return fmt.Errorf("unknown data_security_mode: %s", cluster.DataSecurityMode)
+	}
+	if cluster.RuntimeEngine == "PHOTON" && cluster.DataSecurityMode == "LEGACY_PASSTHROUGH" {
+		return fmt.Errorf("PHOTON runtime_engine is not supported with LEGACY_PASSTHROUGH data_security_mode")
+	}
+	return nil
+}
+
 // TODO: Remove this once all the resources using clusters are migrated to Go SDK.
 // They would then be using ModifyRequestOnInstancePool(cluster *compute.CreateCluster) defined in resource_cluster.go that is a duplicate of this method but uses Go SDK.
 // ModifyRequestOnInstancePool helps remove all request fields that should not be submitted when instance pool is selected.
@@ -497,7 +569,8 @@ func (cluster *Cluster) FixInstancePoolChangeIfAny(d *schema.ResourceData) {
 
 // ClusterList shows existing clusters
 type ClusterList struct {
-	Clusters []ClusterInfo `json:"clusters,omitempty"`
+	Clusters      []ClusterInfo `json:"clusters,omitempty"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
 }
 
 // ClusterInfo contains the information when getting cluster info from the get request.
@@ -552,11 +625,22 @@ func (ci *ClusterInfo) IsRunningOrResizing() bool {
 	return ci.State == ClusterStateRunning || ci.State == ClusterStateResizing
 }
 
+// IsUnityCatalogEnabled returns true if the data security mode of the cluster gives access to Unity Catalog
+func (ci *ClusterInfo) IsUnityCatalogEnabled() bool {
+	return ci.DataSecurityMode == "SINGLE_USER" || ci.DataSecurityMode == "USER_ISOLATION"
+}
+
 // ClusterID holds cluster ID
 type ClusterID struct {
 	ClusterID string `json:"cluster_id,omitempty" url:"cluster_id,omitempty"`
 }
 
+// ChangeOwnerRequest is used to transfer ownership of a cluster to another user
+type ChangeOwnerRequest struct {
+	ClusterID     string `json:"cluster_id"`
+	OwnerUsername string `json:"owner_username"`
+}
+
 func (a ClustersAPI) defaultTimeout() time.Duration {
 	return 30 * time.Minute
 }
@@ -573,6 +657,30 @@ func NewClustersAPI(ctx context.Context, m any) ClustersAPI {
 type ClustersAPI struct {
 	client  *common.DatabricksClient
 	context context.Context
+
+	skipInstanceProfileCheck bool
+}
+
+// WithoutInstanceProfileCheck returns the API that doesn't verify instance profile registration before creating clusters
+func (a ClustersAPI) WithoutInstanceProfileCheck() ClustersAPI {
+	a.skipInstanceProfileCheck = true
+	return a
+}
+
+func (a ClustersAPI) checkInstanceProfile(cluster Cluster) error {
+	if a.skipInstanceProfileCheck || cluster.AwsAttributes == nil || cluster.AwsAttributes.InstanceProfileArn == "" {
+		return nil
+	}
+	arn := cluster.AwsAttributes.InstanceProfileArn
+	_, err := aws.NewInstanceProfilesAPI(a.context, a.client).Read(arn)
+	if apierr.IsMissing(err) {
+		return fmt.Errorf("instance profile %s is not registered in the workspace, "+
+			"add it with databricks_instance_profile resource first", arn)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot check instance profile %s: %w", arn, err)
+	}
+	return nil
 }
 
 // Temporary function to be used until all resources are migrated to Go SDK
@@ -590,6 +698,20 @@ func (a ClustersAPI) Context() context.Context {
 
 // Create creates a new Spark cluster and waits till it's running
 func (a ClustersAPI) Create(cluster Cluster) (info ClusterInfo, err error) {
+	cluster, err = a.applyClusterDefaults(cluster)
+	if err != nil {
+		return
+	}
+	if cluster.Autoscale != nil {
+		err = validateAutoscale(int(cluster.Autoscale.MinWorkers), int(cluster.Autoscale.MaxWorkers))
+		if err != nil {
+			return
+		}
+	}
+	err = a.checkInstanceProfile(cluster)
+	if err != nil {
+		return
+	}
 	var ci ClusterID
 	err = a.client.Post(a.context, "/clusters/create", cluster, &ci)
 	if err != nil {
@@ -597,6 +719,7 @@ func (a ClustersAPI) Create(cluster Cluster) (info ClusterInfo, err error) {
 	}
 	info, err = a.waitForClusterStatus(ci.ClusterID, ClusterStateRunning)
 	if err != nil {
+		info.ClusterID = ci.ClusterID
 		// https://github.com/databricks/terraform-provider-databricks/issues/383
 		log.Printf("[ERROR] Cleaning up created cluster, that failed to start: %s", err.Error())
 		deleteErr := a.PermanentDelete(ci.ClusterID)
@@ -608,6 +731,88 @@ func (a ClustersAPI) Create(cluster Cluster) (info ClusterInfo, err error) {
 	return
 }
 
+// applyClusterDefaults fills the node type and the Spark version configured on the client with
+// SetClusterDefaults, if the cluster doesn't specify them, and resolves Spark version aliases
+func (a ClustersAPI) applyClusterDefaults(cluster Cluster) (Cluster, error) {
+	nodeTypeID, sparkVersion := a.client.ClusterDefaults()
+	if cluster.NodeTypeID == "" && cluster.InstancePoolID == "" {
+		cluster.NodeTypeID = nodeTypeID
+	}
+	if cluster.SparkVersion == "" {
+		cluster.SparkVersion = sparkVersion
+	}
+	if _, ok := sparkVersionAliases[cluster.SparkVersion]; !ok {
+		return cluster, nil
+	}
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return cluster, err
+	}
+	cluster.SparkVersion, err = resolveSparkVersion(a.context, w, cluster.SparkVersion)
+	return cluster, err
+}
+
+// CreateAndRun creates a new cluster, waits till it's running and executes the warmup command on it,
+// all within the given timeout. It returns the ID of the created cluster, even if the cluster fails to start
+// or the command fails.
+func (a ClustersAPI) CreateAndRun(cluster Cluster, warmupCommand, language string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(a.context, timeout)
+	defer cancel()
+	a.context = ctx
+	info, err := a.Create(cluster)
+	if err != nil {
+		return info.ClusterID, err
+	}
+	result := a.client.CommandExecutor(ctx).Execute(info.ClusterID, language, warmupCommand)
+	if result.Failed() {
+		return info.ClusterID, fmt.Errorf("warmup command failed on cluster %s: %w", info.ClusterID, result.Err())
+	}
+	return info.ClusterID, nil
+}
+
+// Clone creates a new cluster with the specification of an existing one, omitting identifiers and runtime state
+// of the source cluster. It doesn't wait for the new cluster to start and returns its ID.
+func (a ClustersAPI) Clone(sourceClusterID, newName string) (string, error) {
+	source, err := a.Get(sourceClusterID)
+	if err != nil {
+		return "", err
+	}
+	cluster := Cluster{
+		ClusterName:               newName,
+		SparkVersion:              source.SparkVersion,
+		NumWorkers:                source.NumWorkers,
+		Autoscale:                 source.AutoScale,
+		EnableElasticDisk:         source.EnableElasticDisk,
+		EnableLocalDiskEncryption: source.EnableLocalDiskEncryption,
+		NodeTypeID:                source.NodeTypeID,
+		DriverNodeTypeID:          source.DriverNodeTypeID,
+		InstancePoolID:            source.InstancePoolID,
+		DriverInstancePoolID:      source.DriverInstancePoolID,
+		AwsAttributes:             source.AwsAttributes,
+		AzureAttributes:           source.AzureAttributes,
+		GcpAttributes:             source.GcpAttributes,
+		AutoterminationMinutes:    source.AutoterminationMinutes,
+		PolicyID:                  source.PolicyID,
+		SparkConf:                 source.SparkConf,
+		SparkEnvVars:              source.SparkEnvVars,
+		CustomTags:                source.CustomTags,
+		SSHPublicKeys:             source.SSHPublicKeys,
+		InitScripts:               source.InitScripts,
+		ClusterLogConf:            source.ClusterLogConf,
+		DockerImage:               source.DockerImage,
+		DataSecurityMode:          source.DataSecurityMode,
+		SingleUserName:            source.SingleUserName,
+		RuntimeEngine:             source.RuntimeEngine,
+	}
+	cluster.ModifyRequestOnInstancePool()
+	var ci ClusterID
+	err = a.client.Post(a.context, "/clusters/create", cluster, &ci)
+	if err != nil {
+		return "", fmt.Errorf("cannot clone cluster %s: %w", sourceClusterID, err)
+	}
+	return ci.ClusterID, nil
+}
+
 // Resize api can only be used when the cluster is in Running State
 func (a ClustersAPI) Resize(resizeRequest ResizeRequest) (info ClusterInfo, err error) {
 	info, err = a.Get(resizeRequest.ClusterID)
@@ -628,6 +833,12 @@ func (a ClustersAPI) Resize(resizeRequest ResizeRequest) (info ClusterInfo, err
 
 // Edit edits the configuration of a cluster to match the provided attributes and size
 func (a ClustersAPI) Edit(cluster Cluster) (info ClusterInfo, err error) {
+	if cluster.Autoscale != nil {
+		err = validateAutoscale(int(cluster.Autoscale.MinWorkers), int(cluster.Autoscale.MaxWorkers))
+		if err != nil {
+			return
+		}
+	}
 	info, err = a.Get(cluster.ClusterID)
 	if err != nil {
 		return info, err
@@ -785,6 +996,36 @@ func (a ClustersAPI) Terminate(clusterID string) error {
 	return err
 }
 
+// TerminateMany terminates given clusters using at most concurrency parallel requests and waits for
+// all of them to reach TERMINATED state. Failure to terminate one cluster doesn't stop termination of
+// the others; all failures are returned together.
+func (a ClustersAPI) TerminateMany(clusterIDs []string, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	indexes := make(chan int)
+	errs := make([]error, len(clusterIDs))
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				err := a.Terminate(clusterIDs[i])
+				if err != nil {
+					errs[i] = fmt.Errorf("cannot terminate cluster %s: %w", clusterIDs[i], err)
+				}
+			}
+		}()
+	}
+	for i := range clusterIDs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
 // PermanentDelete permanently delete a cluster
 func (a ClustersAPI) PermanentDelete(clusterID string) error {
 	err := a.Terminate(clusterID)
@@ -815,6 +1056,47 @@ func (a ClustersAPI) Get(clusterID string) (ci ClusterInfo, err error) {
 	return
 }
 
+// IsUnityCatalogEnabled returns true if the cluster can query Unity Catalog tables
+func (a ClustersAPI) IsUnityCatalogEnabled(clusterID string) (bool, error) {
+	info, err := a.Get(clusterID)
+	if err != nil {
+		return false, err
+	}
+	return info.IsUnityCatalogEnabled(), nil
+}
+
+// ClusterMetrics is a snapshot of memory and CPU usage of a running cluster
+type ClusterMetrics struct {
+	ClusterID    string  `json:"cluster_id"`
+	MemoryUsedMb int64   `json:"memory_used_mb"`
+	CPUUsage     float64 `json:"cpu_usage"`
+}
+
+// SparkContextID returns the identifier of the Spark context of the running cluster
+func (a ClustersAPI) SparkContextID(clusterID string) (int64, error) {
+	info, err := a.Get(clusterID)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsRunningOrResizing() || info.SparkContextID == 0 {
+		return 0, fmt.Errorf("cluster %s is %s, Spark context is available only on running clusters",
+			clusterID, info.State)
+	}
+	return info.SparkContextID, nil
+}
+
+// Metrics returns memory and CPU usage snapshot of the cluster. Clusters API reports only the resources
+// allocated to the cluster, so for existing clusters it returns common.NotSupportedError.
+func (a ClustersAPI) Metrics(clusterID string) (ClusterMetrics, error) {
+	if _, err := a.Get(clusterID); err != nil {
+		return ClusterMetrics{}, err
+	}
+	return ClusterMetrics{}, common.NotSupportedError{
+		Feature: "cluster metrics",
+		Reason:  "clusters API doesn't report memory and CPU usage",
+	}
+}
+
 // Pin ensure that an interactive cluster configuration is retained even after a cluster has been terminated for more than 30 days
 func (a ClustersAPI) Pin(clusterID string) error {
 	return a.client.Post(a.context, "/clusters/pin", ClusterID{ClusterID: clusterID}, nil)
@@ -825,6 +1107,18 @@ func (a ClustersAPI) Unpin(clusterID string) error {
 	return a.client.Post(a.context, "/clusters/unpin", ClusterID{ClusterID: clusterID}, nil)
 }
 
+// ChangeOwner transfers the ownership of a cluster to another user. Only workspace admins can do that.
+func (a ClustersAPI) ChangeOwner(clusterID, newOwnerUserName string) error {
+	err := a.client.Post(a.context, "/clusters/change-owner", ChangeOwnerRequest{
+		ClusterID:     clusterID,
+		OwnerUsername: newOwnerUserName,
+	}, nil)
+	if errors.Is(err, apierr.ErrPermissionDenied) {
+		return fmt.Errorf("only workspace admins can change the owner of cluster %s: %w", clusterID, err)
+	}
+	return err
+}
+
 // Events - only using Cluster ID string to get all events
 // https://docs.databricks.com/dev-tools/api/latest/clusters.html#events
 func (a ClustersAPI) Events(eventsRequest EventsRequest) ([]ClusterEvent, error) {
@@ -863,13 +1157,352 @@ func (a ClustersAPI) Events(eventsRequest EventsRequest) ([]ClusterEvent, error)
 	return events[0:curPos], err
 }
 
+// AutoscalingHistory returns changes of the worker count of the cluster between start and end
+// timestamps in milliseconds, in chronological order
+func (a ClustersAPI) AutoscalingHistory(clusterID string, start, end int64) ([]AutoscaleEvent, error) {
+	events, err := a.Events(EventsRequest{
+		ClusterID:  clusterID,
+		StartTime:  start,
+		EndTime:    end,
+		Order:      SortAscending,
+		EventTypes: []ClusterEventType{EvTypeResizing},
+	})
+	if err != nil {
+		return nil, err
+	}
+	history := []AutoscaleEvent{}
+	for _, event := range events {
+		from, to := event.Details.CurrentNumWorkers, event.Details.TargetNumWorkers
+		if from == to {
+			continue
+		}
+		direction := AutoscaleUpsize
+		if to < from {
+			direction = AutoscaleDownsize
+		}
+		history = append(history, AutoscaleEvent{
+			Timestamp:   event.Timestamp,
+			Direction:   direction,
+			FromWorkers: from,
+			ToWorkers:   to,
+			Cause:       event.Details.ResizeCause,
+		})
+	}
+	return history, nil
+}
+
+// TerminationEvents returns terminations of the cluster between start and end timestamps in milliseconds,
+// in chronological order, with termination reason code and type of each one
+func (a ClustersAPI) TerminationEvents(clusterID string, start, end int64) ([]TerminationEvent, error) {
+	events, err := a.Events(EventsRequest{
+		ClusterID:  clusterID,
+		StartTime:  start,
+		EndTime:    end,
+		Order:      SortAscending,
+		EventTypes: []ClusterEventType{EvTypeTerminating},
+	})
+	if err != nil {
+		return nil, err
+	}
+	terminations := []TerminationEvent{}
+	for _, event := range events {
+		if event.Type != EvTypeTerminating {
+			continue
+		}
+		termination := TerminationEvent{
+			Timestamp: event.Timestamp,
+			User:      event.Details.User,
+		}
+		if reason := event.Details.Reason; reason != nil {
+			termination.Code = reason.Code
+			termination.Type = reason.Type
+			termination.Parameters = reason.Parameters
+		}
+		terminations = append(terminations, termination)
+	}
+	return terminations, nil
+}
+
+// InitScriptExecutionStatus is the outcome of a single init script on the cluster
+type InitScriptExecutionStatus struct {
+	// Destination of the script, like dbfs:/init/install.sh
+	Script string
+	// Global is true for workspace global init scripts
+	Global bool
+	// Status is one of compute.InitScriptExecutionDetailsStatus values
+	Status          compute.InitScriptExecutionDetailsStatus
+	ErrorMessage    string
+	DurationSeconds int
+}
+
+// Succeeded returns true if the script ran successfully
+func (s InitScriptExecutionStatus) Succeeded() bool {
+	return s.Status == compute.InitScriptExecutionDetailsStatusSucceeded
+}
+
+var initScriptFailureMessage = regexp.MustCompile(`init script (\S+) failed`)
+
+// InitScriptStatus returns the status of init scripts from the most recent INIT_SCRIPTS_FINISHED event of
+// the cluster. Workspaces that don't report script details in events only expose the failed script through
+// the cluster termination reason, which is parsed instead.
+func (a ClustersAPI) InitScriptStatus(clusterID string) ([]InitScriptExecutionStatus, error) {
+	events, err := a.Events(EventsRequest{
+		ClusterID:  clusterID,
+		Order:      SortDescending,
+		EventTypes: []ClusterEventType{EvTypeInitScriptsFinished},
+		Limit:      1,
+		MaxItems:   1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > 0 && events[0].Details.InitScripts != nil {
+		details := events[0].Details.InitScripts
+		statuses := []InitScriptExecutionStatus{}
+		for _, script := range details.Global {
+			statuses = append(statuses, newInitScriptExecutionStatus(script, true))
+		}
+		for _, script := range details.Cluster {
+			statuses = append(statuses, newInitScriptExecutionStatus(script, false))
+		}
+		return statuses, nil
+	}
+	clusterInfo, err := a.Get(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	reason := clusterInfo.TerminationReason
+	if reason == nil || reason.Code != "INIT_SCRIPT_FAILURE" {
+		return []InitScriptExecutionStatus{}, nil
+	}
+	message := reason.Parameters["databricks_error_message"]
+	status := InitScriptExecutionStatus{
+		Status:       compute.InitScriptExecutionDetailsStatusFailedExecution,
+		ErrorMessage: message,
+		Global:       strings.HasPrefix(strings.ToLower(message), "global init script"),
+	}
+	if match := initScriptFailureMessage.FindStringSubmatch(message); match != nil {
+		status.Script = match[1]
+	}
+	return []InitScriptExecutionStatus{status}, nil
+}
+
+// InitScriptRef is an init script that runs when the cluster starts
+type InitScriptRef struct {
+	// Global is true for workspace global init scripts
+	Global bool
+	// Name and ScriptID are set for global init scripts
+	Name     string
+	ScriptID string
+	// Storage is set for cluster init scripts
+	Storage *InitScriptStorageInfo
+}
+
+// EffectiveInitScripts returns the init scripts that run when the cluster starts, in the order of execution:
+// enabled global init scripts by their position, followed by cluster init scripts in the configured order
+func (a ClustersAPI) EffectiveInitScripts(clusterID string) ([]InitScriptRef, error) {
+	clusterInfo, err := a.Get(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return nil, err
+	}
+	globalScripts, err := w.GlobalInitScripts.ListAll(a.context)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(globalScripts, func(i, j int) bool {
+		return globalScripts[i].Position < globalScripts[j].Position
+	})
+	scripts := []InitScriptRef{}
+	for _, script := range globalScripts {
+		if !script.Enabled {
+			continue
+		}
+		scripts = append(scripts, InitScriptRef{
+			Global:   true,
+			Name:     script.Name,
+			ScriptID: script.ScriptId,
+		})
+	}
+	for i := range clusterInfo.InitScripts {
+		scripts = append(scripts, InitScriptRef{
+			Storage: &clusterInfo.InitScripts[i],
+		})
+	}
+	return scripts, nil
+}
+
+func newInitScriptExecutionStatus(script compute.InitScriptInfoAndExecutionDetails, global bool) InitScriptExecutionStatus {
+	status := InitScriptExecutionStatus{
+		Global: global,
+		Status: compute.InitScriptExecutionDetailsStatusUnknown,
+	}
+	if script.ExecutionDetails != nil {
+		status.Status = script.ExecutionDetails.Status
+		status.ErrorMessage = script.ExecutionDetails.ErrorMessage
+		status.DurationSeconds = script.ExecutionDetails.ExecutionDurationSeconds
+	}
+	if s := script.Script; s != nil {
+		switch {
+		case s.Dbfs != nil:
+			status.Script = s.Dbfs.Destination
+		case s.Workspace != nil:
+			status.Script = s.Workspace.Destination
+		case s.Volumes != nil:
+			status.Script = s.Volumes.Destination
+		case s.S3 != nil:
+			status.Script = s.S3.Destination
+		case s.Abfss != nil:
+			status.Script = s.Abfss.Destination
+		case s.Gcs != nil:
+			status.Script = s.Gcs.Destination
+		case s.File != nil:
+			status.Script = s.File.Destination
+		}
+	}
+	return status
+}
+
 // List return information about all pinned clusters, currently active clusters,
 // up to 70 of the most recently terminated interactive clusters in the past 30 days,
 // and up to 30 of the most recently terminated job clusters in the past 30 days
 func (a ClustersAPI) List() ([]ClusterInfo, error) {
+	all := []ClusterInfo{}
+	pageToken := ""
+	for {
+		clusters, nextPageToken, err := a.ListPaginated(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, clusters...)
+		if nextPageToken == "" {
+			return all, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// ListPaginated returns a single page of clusters starting at pageToken, which is empty for the first page,
+// together with the token of the next page. Next page token is empty on the last page.
+func (a ClustersAPI) ListPaginated(pageToken string) ([]ClusterInfo, string, error) {
+	var request any
+	if pageToken != "" {
+		request = map[string]any{
+			"page_token": pageToken,
+		}
+	}
+	// only API 2.1 supports pagination of the cluster list
+	ctx := context.WithValue(a.context, common.Api, common.API_2_1)
 	var clusterList ClusterList
-	err := a.client.Get(a.context, "/clusters/list", nil, &clusterList)
-	return clusterList.Clusters, err
+	err := a.client.Get(ctx, "/clusters/list", request, &clusterList)
+	return clusterList.Clusters, clusterList.NextPageToken, err
+}
+
+// tagLookupConcurrency limits the number of clusters fetched in parallel by ListByTag
+const tagLookupConcurrency = 10
+
+// ListByTag returns clusters with the given value of the custom or default tag. Clusters listed without
+// custom tags are fetched one by one, as the list doesn't always include them.
+func (a ClustersAPI) ListByTag(key, value string) ([]ClusterInfo, error) {
+	all, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]bool, len(all))
+	lookups := []int{}
+	for i, cluster := range all {
+		matched[i] = cluster.hasTag(key, value)
+		if !matched[i] && cluster.CustomTags == nil {
+			lookups = append(lookups, i)
+		}
+	}
+	indexes := make(chan int)
+	errs := make([]error, len(all))
+	var wg sync.WaitGroup
+	for i := 0; i < min(tagLookupConcurrency, len(lookups)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				info, err := a.Get(all[i].ClusterID)
+				if apierr.IsMissing(err) {
+					continue
+				}
+				if err != nil {
+					errs[i] = fmt.Errorf("cannot get cluster %s: %w", all[i].ClusterID, err)
+					continue
+				}
+				all[i] = info
+				matched[i] = info.hasTag(key, value)
+			}
+		}()
+	}
+	for _, i := range lookups {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	if err = errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	tagged := []ClusterInfo{}
+	for i, cluster := range all {
+		if matched[i] {
+			tagged = append(tagged, cluster)
+		}
+	}
+	return tagged, nil
+}
+
+func (ci *ClusterInfo) hasTag(key, value string) bool {
+	if v, ok := ci.CustomTags[key]; ok && v == value {
+		return true
+	}
+	v, ok := ci.DefaultTags[key]
+	return ok && v == value
+}
+
+// TerminatedBefore returns terminated clusters with termination time before the given cutoff,
+// which makes them candidates for PermanentDelete in cleanup jobs
+func (a ClustersAPI) TerminatedBefore(cutoff time.Time) ([]ClusterInfo, error) {
+	all, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+	terminated := []ClusterInfo{}
+	for _, cluster := range all {
+		if cluster.State != ClusterStateTerminated || cluster.TerminateTime == 0 {
+			continue
+		}
+		if time.UnixMilli(cluster.TerminateTime).Before(cutoff) {
+			terminated = append(terminated, cluster)
+		}
+	}
+	return terminated, nil
+}
+
+// PinnedClusters returns IDs of all pinned clusters
+func (a ClustersAPI) PinnedClusters() ([]string, error) {
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return nil, err
+	}
+	all, err := w.Clusters.ListAll(a.context, compute.ListClustersRequest{
+		FilterBy: &compute.ListClustersFilterBy{
+			IsPinned: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	pinned := []string{}
+	for _, cluster := range all {
+		pinned = append(pinned, cluster.ClusterId)
+	}
+	return pinned, nil
 }
 
 // getOrCreateClusterMutex guards "mounting" cluster creation to prevent multiple
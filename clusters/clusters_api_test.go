@@ -7,10 +7,12 @@ import (
 	// "reflect"
 
 	"testing"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/aws"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
@@ -21,7 +23,7 @@ func TestGetOrCreateRunningCluster_AzureAuth(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/list",
+			Resource: "/api/2.1/clusters/list",
 			Response: map[string]any{},
 		},
 		{
@@ -120,7 +122,7 @@ func TestGetOrCreateRunningCluster_Existing_AzureAuth(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/list",
+			Resource: "/api/2.1/clusters/list",
 			Response: ClusterList{
 				Clusters: []ClusterInfo{
 					{
@@ -1168,3 +1170,988 @@ func TestExpiredClusterAssumedAsRemoved(t *testing.T) {
 	}, "X")
 	assert.EqualError(t, err, databricks.ErrResourceDoesNotExist.Error())
 }
+
+func TestClusterMetrics(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:       "abc",
+				State:           ClusterStateRunning,
+				NumWorkers:      2,
+				ClusterMemoryMb: 49152,
+				ClusterCores:    12,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=bcd",
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Cluster bcd does not exist",
+			},
+			Status: 404,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewClustersAPI(ctx, client)
+		_, err := a.Metrics("abc")
+		var nse common.NotSupportedError
+		assert.ErrorAs(t, err, &nse)
+		assert.EqualError(t, err, "cluster metrics is not supported: clusters API doesn't report memory and CPU usage")
+
+		_, err = a.Metrics("bcd")
+		assert.True(t, apierr.IsMissing(err))
+	})
+}
+
+func TestClusterSparkContextID(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: map[string]any{
+				"cluster_id":       "abc",
+				"state":            "RUNNING",
+				"spark_context_id": 4020997813441462000,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=bcd",
+			Response: ClusterInfo{
+				ClusterID: "bcd",
+				State:     ClusterStateTerminated,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewClustersAPI(ctx, client)
+		id, err := a.SparkContextID("abc")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4020997813441462000), id)
+
+		_, err = a.SparkContextID("bcd")
+		assert.EqualError(t, err, "cluster bcd is TERMINATED, Spark context is available only on running clusters")
+	})
+}
+
+func TestClusterChangeOwner(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/change-owner",
+			ExpectedRequest: ChangeOwnerRequest{
+				ClusterID:     "abc",
+				OwnerUsername: "new@example.com",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/change-owner",
+			ExpectedRequest: ChangeOwnerRequest{
+				ClusterID:     "bcd",
+				OwnerUsername: "new@example.com",
+			},
+			Status: 403,
+			Response: common.APIErrorBody{
+				ErrorCode: "PERMISSION_DENIED",
+				Message:   "Only admins can change cluster owner",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewClustersAPI(ctx, client)
+		err := a.ChangeOwner("abc", "new@example.com")
+		assert.NoError(t, err)
+
+		err = a.ChangeOwner("bcd", "new@example.com")
+		assert.EqualError(t, err, "only workspace admins can change the owner of cluster bcd: Only admins can change cluster owner")
+	})
+}
+
+func TestClusterValidateUnityCatalog(t *testing.T) {
+	assert.NoError(t, Cluster{
+		RuntimeEngine:    "PHOTON",
+		DataSecurityMode: "SINGLE_USER",
+		SingleUserName:   "someone@example.com",
+	}.ValidateUnityCatalog())
+	assert.NoError(t, Cluster{
+		DataSecurityMode: "USER_ISOLATION",
+	}.ValidateUnityCatalog())
+
+	assert.EqualError(t, Cluster{
+		RuntimeEngine:    "PHOTON",
+		DataSecurityMode: "SINGLE_USER",
+	}.ValidateUnityCatalog(), "single_user_name is required for SINGLE_USER data_security_mode")
+	assert.EqualError(t, Cluster{
+		DataSecurityMode: "USER_ISOLATION",
+		SingleUserName:   "someone@example.com",
+	}.ValidateUnityCatalog(), "single_user_name can only be used with SINGLE_USER data_security_mode, got USER_ISOLATION")
+	assert.EqualError(t, Cluster{
+		RuntimeEngine:    "PHOTON",
+		DataSecurityMode: "LEGACY_PASSTHROUGH",
+	}.ValidateUnityCatalog(), "PHOTON runtime_engine is not supported with LEGACY_PASSTHROUGH data_security_mode")
+	assert.EqualError(t, Cluster{
+		RuntimeEngine: "TURBO",
+	}.ValidateUnityCatalog(), "runtime_engine must be STANDARD or PHOTON, got TURBO")
+}
+
+func TestClusterValidateAutoscale(t *testing.T) {
+	assert.NoError(t, Cluster{
+		Autoscale: &AutoScale{MinWorkers: 1, MaxWorkers: 4},
+	}.Validate())
+	assert.NoError(t, Cluster{
+		Autoscale: &AutoScale{MinWorkers: 3, MaxWorkers: 3},
+	}.Validate())
+	assert.EqualError(t, Cluster{
+		Autoscale: &AutoScale{MinWorkers: 5, MaxWorkers: 2},
+	}.Validate(), "autoscale min_workers (5) must not be greater than max_workers (2)")
+	assert.EqualError(t, Cluster{
+		Autoscale: &AutoScale{MinWorkers: 1},
+	}.Validate(), "autoscale max_workers must be greater than 0, got 0")
+}
+
+func TestClusterCreate_InvertedAutoscale(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		_, err := NewClustersAPI(ctx, client).Create(Cluster{
+			ClusterName:  "Shared Autoscaling",
+			SparkVersion: "14.3.x-scala2.12",
+			NodeTypeID:   "i3.xlarge",
+			Autoscale:    &AutoScale{MinWorkers: 5, MaxWorkers: 2},
+		})
+		assert.EqualError(t, err, "autoscale min_workers (5) must not be greater than max_workers (2)")
+	})
+}
+
+func TestClustersAPIIsUnityCatalogEnabled(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:        "abc",
+				State:            ClusterStateRunning,
+				DataSecurityMode: "USER_ISOLATION",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=bcd",
+			Response: ClusterInfo{
+				ClusterID:        "bcd",
+				State:            ClusterStateRunning,
+				DataSecurityMode: "NONE",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewClustersAPI(ctx, client)
+		enabled, err := a.IsUnityCatalogEnabled("abc")
+		require.NoError(t, err)
+		assert.True(t, enabled)
+
+		enabled, err = a.IsUnityCatalogEnabled("bcd")
+		require.NoError(t, err)
+		assert.False(t, enabled)
+	})
+	assert.True(t, (&ClusterInfo{DataSecurityMode: "SINGLE_USER"}).IsUnityCatalogEnabled())
+	assert.False(t, (&ClusterInfo{DataSecurityMode: "LEGACY_TABLE_ACL"}).IsUnityCatalogEnabled())
+}
+
+func TestClusterCreate_ClientDefaults(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/spark-versions",
+			Response: compute.GetSparkVersionsResponse{
+				Versions: []compute.SparkVersion{
+					{
+						Key:  "14.3.x-scala2.12",
+						Name: "14.3 LTS (includes Apache Spark 3.5.0, Scala 2.12)",
+					},
+					{
+						Key:  "15.1.x-scala2.12",
+						Name: "15.1 (includes Apache Spark 3.5.0, Scala 2.12)",
+					},
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			ExpectedRequest: Cluster{
+				ClusterName:  "Defaults",
+				SparkVersion: "14.3.x-scala2.12",
+				NodeTypeID:   "i3.xlarge",
+				NumWorkers:   1,
+			},
+			Response: ClusterID{
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateRunning,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			ExpectedRequest: Cluster{
+				ClusterName:    "Pooled",
+				SparkVersion:   "15.1.x-scala2.12",
+				InstancePoolID: "pool",
+				NumWorkers:     1,
+			},
+			Response: ClusterID{
+				ClusterID: "bcd",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=bcd",
+			Response: ClusterInfo{
+				ClusterID: "bcd",
+				State:     ClusterStateRunning,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.SetClusterDefaults("i3.xlarge", "auto:latest-lts")
+		a := NewClustersAPI(ctx, client)
+		_, err := a.Create(Cluster{
+			ClusterName: "Defaults",
+			NumWorkers:  1,
+		})
+		require.NoError(t, err)
+
+		_, err = a.Create(Cluster{
+			ClusterName:    "Pooled",
+			SparkVersion:   "15.1.x-scala2.12",
+			InstancePoolID: "pool",
+			NumWorkers:     1,
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestClusterCreateAndRun(t *testing.T) {
+	cluster := Cluster{
+		ClusterName:  "Warm",
+		SparkVersion: "14.3.x-scala2.12",
+		NodeTypeID:   "i3.xlarge",
+		NumWorkers:   1,
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/clusters/create",
+			ExpectedRequest: cluster,
+			Response: ClusterID{
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStatePending,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateRunning,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.WithCommandMock(func(commandStr string) common.CommandResults {
+			assert.Equal(t, "%pip install requests", commandStr)
+			return common.CommandResults{
+				ResultType: "text",
+				Data:       "Successfully installed requests",
+			}
+		})
+		clusterID, err := NewClustersAPI(ctx, client).CreateAndRun(cluster,
+			"%pip install requests", "python", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "abc", clusterID)
+	})
+}
+
+func TestClusterCreateAndRun_NotStarted(t *testing.T) {
+	cluster := Cluster{
+		ClusterName:  "Warm",
+		SparkVersion: "14.3.x-scala2.12",
+		NodeTypeID:   "i3.xlarge",
+		NumWorkers:   1,
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/clusters/create",
+			ExpectedRequest: cluster,
+			Response: ClusterID{
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:    "abc",
+				State:        ClusterStateTerminated,
+				StateMessage: "Cloud provider quota exceeded",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/delete",
+			ExpectedRequest: ClusterID{
+				ClusterID: "abc",
+			},
+			Status: 400,
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_STATE",
+				Message:   "Cluster abc is in unexpected state",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		clusterID, err := NewClustersAPI(ctx, client).CreateAndRun(cluster,
+			"%pip install requests", "python", time.Minute)
+		assert.EqualError(t, err, "Cluster abc is in unexpected state")
+		assert.Equal(t, "abc", clusterID)
+	})
+}
+
+func TestClusterClone(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:              "abc",
+				ClusterName:            "Source",
+				CreatorUserName:        "someone@example.com",
+				SparkVersion:           "14.3.x-scala2.12",
+				NodeTypeID:             "i3.xlarge",
+				DriverNodeTypeID:       "i3.xlarge",
+				NumWorkers:             2,
+				AutoterminationMinutes: 30,
+				SparkContextID:         123,
+				State:                  ClusterStateRunning,
+				StateMessage:           "Running",
+				StartTime:              1700000000000,
+				DefaultTags: map[string]string{
+					"ClusterId": "abc",
+				},
+				CustomTags: map[string]string{
+					"team": "data",
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			ExpectedRequest: map[string]any{
+				"cluster_name":            "Clone",
+				"spark_version":           "14.3.x-scala2.12",
+				"node_type_id":            "i3.xlarge",
+				"driver_node_type_id":     "i3.xlarge",
+				"num_workers":             2,
+				"autotermination_minutes": 30,
+				"custom_tags": map[string]any{
+					"team": "data",
+				},
+			},
+			Response: ClusterID{
+				ClusterID: "bcd",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewClustersAPI(ctx, client)
+		clusterID, err := a.Clone("abc", "Clone")
+		require.NoError(t, err)
+		assert.Equal(t, "bcd", clusterID)
+	})
+}
+
+func TestClustersInitScriptStatus(t *testing.T) {
+	eventsRequest := EventsRequest{
+		ClusterID:  "abc",
+		Order:      SortDescending,
+		EventTypes: []ClusterEventType{EvTypeInitScriptsFinished},
+		Limit:      1,
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/clusters/events",
+			ExpectedRequest: eventsRequest,
+			Response: `{
+				"events": [{
+					"cluster_id": "abc",
+					"timestamp": 1000,
+					"type": "INIT_SCRIPTS_FINISHED",
+					"details": {
+						"init_scripts": {
+							"global": [{
+								"script": {"workspace": {"destination": "/Shared/global.sh"}},
+								"execution_details": {"status": "SUCCEEDED", "execution_duration_seconds": 3}
+							}],
+							"cluster": [{
+								"script": {"volumes": {"destination": "/Volumes/main/default/scripts/install.sh"}},
+								"execution_details": {"status": "FAILED_EXECUTION", "error_message": "exit code 1"}
+							}]
+						}
+					}
+				}],
+				"total_count": 1
+			}`,
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/clusters/events",
+			ExpectedRequest: eventsRequest,
+			Response: EventsResponse{
+				TotalCount: 0,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateTerminated,
+				TerminationReason: &TerminationReason{
+					Code: "INIT_SCRIPT_FAILURE",
+					Type: "CLIENT_ERROR",
+					Parameters: map[string]string{
+						"instance_id":              "i-123",
+						"databricks_error_message": "Cluster scoped init script dbfs:/init/install.sh failed: Script exit status is non-zero",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewClustersAPI(ctx, client)
+		statuses, err := a.InitScriptStatus("abc")
+		require.NoError(t, err)
+		assert.Equal(t, []InitScriptExecutionStatus{
+			{
+				Script:          "/Shared/global.sh",
+				Global:          true,
+				Status:          compute.InitScriptExecutionDetailsStatusSucceeded,
+				DurationSeconds: 3,
+			},
+			{
+				Script:       "/Volumes/main/default/scripts/install.sh",
+				Status:       compute.InitScriptExecutionDetailsStatusFailedExecution,
+				ErrorMessage: "exit code 1",
+			},
+		}, statuses)
+
+		statuses, err = a.InitScriptStatus("abc")
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		assert.False(t, statuses[0].Succeeded())
+		assert.False(t, statuses[0].Global)
+		assert.Equal(t, "dbfs:/init/install.sh", statuses[0].Script)
+		assert.Equal(t, "Cluster scoped init script dbfs:/init/install.sh failed: Script exit status is non-zero",
+			statuses[0].ErrorMessage)
+	})
+}
+
+func TestClustersListPaginated(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.1/clusters/list",
+			ReuseRequest: true,
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{
+						ClusterID: "abc",
+					},
+					{
+						ClusterID: "bcd",
+					},
+				},
+				NextPageToken: "next",
+			},
+		},
+		{
+			Method:       "GET",
+			Resource:     "/api/2.1/clusters/list?page_token=next",
+			ReuseRequest: true,
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{
+						ClusterID: "cde",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewClustersAPI(ctx, client)
+		all, err := a.List()
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.Equal(t, "cde", all[2].ClusterID)
+
+		page, nextPageToken, err := a.ListPaginated("")
+		require.NoError(t, err)
+		assert.Len(t, page, 2)
+		assert.Equal(t, "next", nextPageToken)
+
+		page, nextPageToken, err = a.ListPaginated(nextPageToken)
+		require.NoError(t, err)
+		assert.Len(t, page, 1)
+		assert.Equal(t, "", nextPageToken)
+	})
+}
+
+func TestPinnedClusters(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/list?filter_by.is_pinned=true",
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{
+						ClusterID: "abc",
+						State:     ClusterStateRunning,
+					},
+					{
+						ClusterID: "bcd",
+						State:     ClusterStateTerminated,
+					},
+				},
+				NextPageToken: "next",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/list?filter_by.is_pinned=true&page_token=next",
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{
+						ClusterID: "cde",
+						State:     ClusterStateTerminated,
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		pinned, err := NewClustersAPI(ctx, client).PinnedClusters()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"abc", "bcd", "cde"}, pinned)
+	})
+}
+
+func TestClustersAutoscalingHistory(t *testing.T) {
+	autoscale := ResizeCause("AUTOSCALE")
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/events",
+			ExpectedRequest: EventsRequest{
+				ClusterID:  "abc",
+				StartTime:  1000,
+				EndTime:    5000,
+				Order:      SortAscending,
+				EventTypes: []ClusterEventType{EvTypeResizing},
+			},
+			Response: EventsResponse{
+				Events: []ClusterEvent{
+					{
+						ClusterID: "abc",
+						Timestamp: 1100,
+						Type:      EvTypeResizing,
+						Details: EventDetails{
+							CurrentNumWorkers: 2,
+							TargetNumWorkers:  6,
+							ResizeCause:       &autoscale,
+						},
+					},
+					{
+						ClusterID: "abc",
+						Timestamp: 2200,
+						Type:      EvTypeResizing,
+						Details: EventDetails{
+							CurrentNumWorkers: 6,
+							TargetNumWorkers:  6,
+						},
+					},
+					{
+						ClusterID: "abc",
+						Timestamp: 3300,
+						Type:      EvTypeResizing,
+						Details: EventDetails{
+							CurrentNumWorkers: 6,
+							TargetNumWorkers:  3,
+							ResizeCause:       &autoscale,
+						},
+					},
+				},
+				TotalCount: 3,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		history, err := NewClustersAPI(ctx, client).AutoscalingHistory("abc", 1000, 5000)
+		require.NoError(t, err)
+		assert.Equal(t, []AutoscaleEvent{
+			{
+				Timestamp:   1100,
+				Direction:   AutoscaleUpsize,
+				FromWorkers: 2,
+				ToWorkers:   6,
+				Cause:       &autoscale,
+			},
+			{
+				Timestamp:   3300,
+				Direction:   AutoscaleDownsize,
+				FromWorkers: 6,
+				ToWorkers:   3,
+				Cause:       &autoscale,
+			},
+		}, history)
+	})
+}
+
+func TestClustersTerminationEvents(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/events",
+			ExpectedRequest: EventsRequest{
+				ClusterID:  "abc",
+				StartTime:  1000,
+				EndTime:    5000,
+				Order:      SortAscending,
+				EventTypes: []ClusterEventType{EvTypeTerminating},
+			},
+			Response: EventsResponse{
+				Events: []ClusterEvent{
+					{
+						ClusterID: "abc",
+						Timestamp: 1100,
+						Type:      EvTypeTerminating,
+						Details: EventDetails{
+							Reason: &TerminationReason{
+								Code: "INACTIVITY",
+								Type: "SUCCESS",
+								Parameters: map[string]string{
+									"inactivity_duration_min": "60",
+								},
+							},
+						},
+					},
+					{
+						ClusterID: "abc",
+						Timestamp: 3300,
+						Type:      EvTypeTerminating,
+						Details: EventDetails{
+							User: "me@example.com",
+							Reason: &TerminationReason{
+								Code: "CLOUD_PROVIDER_LAUNCH_FAILURE",
+								Type: "CLOUD_FAILURE",
+							},
+						},
+					},
+					{
+						ClusterID: "abc",
+						Timestamp: 4400,
+						Type:      EvTypeTerminating,
+					},
+				},
+				TotalCount: 3,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		terminations, err := NewClustersAPI(ctx, client).TerminationEvents("abc", 1000, 5000)
+		require.NoError(t, err)
+		assert.Equal(t, []TerminationEvent{
+			{
+				Timestamp: 1100,
+				Code:      "INACTIVITY",
+				Type:      "SUCCESS",
+				Parameters: map[string]string{
+					"inactivity_duration_min": "60",
+				},
+			},
+			{
+				Timestamp: 3300,
+				Code:      "CLOUD_PROVIDER_LAUNCH_FAILURE",
+				Type:      "CLOUD_FAILURE",
+				User:      "me@example.com",
+			},
+			{
+				Timestamp: 4400,
+			},
+		}, terminations)
+	})
+}
+
+func TestClustersEffectiveInitScripts(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				InitScripts: []InitScriptStorageInfo{
+					{Workspace: &WorkspaceFileInfo{Destination: "/Shared/first.sh"}},
+					{Dbfs: &DbfsStorageInfo{Destination: "dbfs:/init/second.sh"}},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/global-init-scripts",
+			Response: compute.ListGlobalInitScriptsResponse{
+				Scripts: []compute.GlobalInitScriptDetails{
+					{
+						ScriptId: "disabled",
+						Name:     "Disabled",
+						Position: 0,
+					},
+					{
+						ScriptId: "proxy",
+						Name:     "Proxy",
+						Position: 1,
+						Enabled:  true,
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		scripts, err := NewClustersAPI(ctx, client).EffectiveInitScripts("abc")
+		require.NoError(t, err)
+		assert.Equal(t, []InitScriptRef{
+			{
+				Global:   true,
+				Name:     "Proxy",
+				ScriptID: "proxy",
+			},
+			{
+				Storage: &InitScriptStorageInfo{
+					Workspace: &WorkspaceFileInfo{Destination: "/Shared/first.sh"},
+				},
+			},
+			{
+				Storage: &InitScriptStorageInfo{
+					Dbfs: &DbfsStorageInfo{Destination: "dbfs:/init/second.sh"},
+				},
+			},
+		}, scripts)
+	})
+}
+
+func TestClustersListByTag(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/list",
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{
+						ClusterID:  "custom",
+						CustomTags: map[string]string{"cost-center": "finance"},
+					},
+					{
+						ClusterID:  "other",
+						CustomTags: map[string]string{"cost-center": "marketing"},
+					},
+					{
+						ClusterID:   "default",
+						DefaultTags: map[string]string{"cost-center": "finance"},
+					},
+					{
+						ClusterID: "untagged-in-list",
+					},
+					{
+						ClusterID: "removed",
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=untagged-in-list",
+			Response: ClusterInfo{
+				ClusterID:  "untagged-in-list",
+				CustomTags: map[string]string{"cost-center": "finance"},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=removed",
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Cluster removed does not exist",
+			},
+			Status: 404,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		tagged, err := NewClustersAPI(ctx, client).ListByTag("cost-center", "finance")
+		require.NoError(t, err)
+		var ids []string
+		for _, cluster := range tagged {
+			ids = append(ids, cluster.ClusterID)
+		}
+		assert.Equal(t, []string{"custom", "default", "untagged-in-list"}, ids)
+	})
+}
+
+func TestClustersTerminatedBefore(t *testing.T) {
+	cutoff := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/list",
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{
+						ClusterID: "running",
+						State:     ClusterStateRunning,
+					},
+					{
+						ClusterID:     "old",
+						State:         ClusterStateTerminated,
+						TerminateTime: cutoff.Add(-48 * time.Hour).UnixMilli(),
+					},
+					{
+						ClusterID:     "recent",
+						State:         ClusterStateTerminated,
+						TerminateTime: cutoff.Add(time.Hour).UnixMilli(),
+					},
+					{
+						ClusterID:     "restarted",
+						State:         ClusterStatePending,
+						TerminateTime: cutoff.Add(-48 * time.Hour).UnixMilli(),
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		terminated, err := NewClustersAPI(ctx, client).TerminatedBefore(cutoff)
+		require.NoError(t, err)
+		require.Len(t, terminated, 1)
+		assert.Equal(t, "old", terminated[0].ClusterID)
+	})
+}
+
+func TestClusterCreate_UnregisteredInstanceProfile(t *testing.T) {
+	cluster := Cluster{
+		ClusterName:  "Profiled",
+		SparkVersion: "14.3.x-scala2.12",
+		NodeTypeID:   "i3.xlarge",
+		NumWorkers:   1,
+		AwsAttributes: &AwsAttributes{
+			InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/unknown",
+		},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-profiles/list",
+			Response: aws.InstanceProfileList{
+				InstanceProfiles: []aws.InstanceProfileInfo{
+					{
+						InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/known",
+					},
+				},
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/clusters/create",
+			ExpectedRequest: cluster,
+			Response: ClusterID{
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateRunning,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewClustersAPI(ctx, client)
+		_, err := a.Create(cluster)
+		assert.EqualError(t, err, "instance profile arn:aws:iam::1234567:instance-profile/unknown is not registered "+
+			"in the workspace, add it with databricks_instance_profile resource first")
+
+		info, err := a.WithoutInstanceProfileCheck().Create(cluster)
+		require.NoError(t, err)
+		assert.Equal(t, "abc", info.ClusterID)
+	})
+}
+
+func TestClusterCreate_InstanceProfileCheckError(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-profiles/list",
+			Response: common.APIErrorBody{
+				ErrorCode: "SERVER_ERROR",
+				Message:   "Something unexpected happened",
+			},
+			Status: 500,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		_, err := NewClustersAPI(ctx, client).Create(Cluster{
+			ClusterName:  "Profiled",
+			SparkVersion: "14.3.x-scala2.12",
+			NodeTypeID:   "i3.xlarge",
+			NumWorkers:   1,
+			AwsAttributes: &AwsAttributes{
+				InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/known",
+			},
+		})
+		assert.EqualError(t, err, "cannot check instance profile arn:aws:iam::1234567:instance-profile/known: "+
+			"Something unexpected happened")
+	})
+}
+
+func TestClustersTerminateMany(t *testing.T) {
+	fixtures := []qa.HTTPFixture{
+		{
+			Method:       "POST",
+			Resource:     "/api/2.0/clusters/delete",
+			ReuseRequest: true,
+		},
+	}
+	for _, id := range []string{"a", "b", "d", "e"} {
+		fixtures = append(fixtures, qa.HTTPFixture{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=" + id,
+			Response: ClusterInfo{
+				ClusterID: id,
+				State:     ClusterStateTerminated,
+			},
+		})
+	}
+	fixtures = append(fixtures, qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/clusters/get?cluster_id=c",
+		Response: ClusterInfo{
+			ClusterID:    "c",
+			State:        ClusterStateError,
+			StateMessage: "cloud provider failure",
+		},
+	})
+	qa.HTTPFixturesApply(t, fixtures, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewClustersAPI(ctx, client).TerminateMany([]string{"a", "b", "c", "d", "e"}, 2)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "cannot terminate cluster c: c is not able to transition from ERROR to TERMINATED")
+		assert.NotContains(t, err.Error(), "cluster a")
+	})
+}
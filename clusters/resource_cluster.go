@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
+	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/terraform-provider-databricks/common"
@@ -103,6 +105,28 @@ func SparkConfDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool
 	return false
 }
 
+var secretReference = regexp.MustCompile(`^\{\{\s*secrets/[^/}]+/[^}]+\}\}$`)
+
+// reconcileSparkConf returns the Spark configuration read from the cluster, where the keys configured with
+// a secret reference keep the configured value, if the cluster returns the same secret reference formatted
+// with different whitespace. All other keys are taken literally from the cluster.
+func reconcileSparkConf(configured map[string]any, actual map[string]string) map[string]string {
+	reconciled := make(map[string]string, len(actual))
+	for k, v := range actual {
+		reconciled[k] = v
+		configuredValue, ok := configured[k].(string)
+		if ok && secretReference.MatchString(configuredValue) &&
+			normalizeSecretReference(configuredValue) == normalizeSecretReference(v) {
+			reconciled[k] = configuredValue
+		}
+	}
+	return reconciled
+}
+
+func normalizeSecretReference(v string) string {
+	return strings.Join(strings.Fields(v), "")
+}
+
 func ZoneDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
 	if old != "" && (new == "auto" || new == "") {
 		log.Printf("[INFO] Suppressing diff on availability zone")
@@ -117,21 +141,30 @@ func Validate(cluster any) error {
 	var profile, master, resourceClass string
 	switch c := cluster.(type) {
 	case compute.CreateCluster:
-		if c.NumWorkers > 0 || c.Autoscale != nil {
+		if c.Autoscale != nil {
+			return validateAutoscale(c.Autoscale.MinWorkers, c.Autoscale.MaxWorkers)
+		}
+		if c.NumWorkers > 0 {
 			return nil
 		}
 		profile = c.SparkConf["spark.databricks.cluster.profile"]
 		master = c.SparkConf["spark.master"]
 		resourceClass = c.CustomTags["ResourceClass"]
 	case compute.EditCluster:
-		if c.NumWorkers > 0 || c.Autoscale != nil {
+		if c.Autoscale != nil {
+			return validateAutoscale(c.Autoscale.MinWorkers, c.Autoscale.MaxWorkers)
+		}
+		if c.NumWorkers > 0 {
 			return nil
 		}
 		profile = c.SparkConf["spark.databricks.cluster.profile"]
 		master = c.SparkConf["spark.master"]
 		resourceClass = c.CustomTags["ResourceClass"]
 	case compute.ClusterSpec:
-		if c.NumWorkers > 0 || c.Autoscale != nil {
+		if c.Autoscale != nil {
+			return validateAutoscale(c.Autoscale.MinWorkers, c.Autoscale.MaxWorkers)
+		}
+		if c.NumWorkers > 0 {
 			return nil
 		}
 		profile = c.SparkConf["spark.databricks.cluster.profile"]
@@ -301,6 +334,68 @@ func SetForceSendFieldsForCluster(cluster any, d *schema.ResourceData) error {
 	}
 }
 
+// warnOnLocalDiskEncryption logs a warning when local disk encryption is requested for a node type that
+// is not known to the workspace, because the support for encryption cannot be verified in this case.
+func warnOnLocalDiskEncryption(ctx context.Context, w *databricks.WorkspaceClient, enabled bool, nodeTypeId string) {
+	if !enabled || nodeTypeId == "" {
+		return
+	}
+	nodeTypes, err := w.Clusters.ListNodeTypes(ctx)
+	if err != nil {
+		log.Printf("[WARN] Cannot verify that %s supports local disk encryption: %s", nodeTypeId, err)
+		return
+	}
+	for _, nt := range nodeTypes.NodeTypes {
+		if nt.NodeTypeId == nodeTypeId {
+			return
+		}
+	}
+	log.Printf("[WARN] Node type %s is unknown, so the support for local disk encryption cannot be verified", nodeTypeId)
+}
+
+// sparkVersionDeprecationWarning returns a warning if the given Databricks Runtime version is no longer
+// listed among the versions supported by the workspace
+func sparkVersionDeprecationWarning(ctx context.Context, w *databricks.WorkspaceClient, sparkVersion string) (string, error) {
+	if sparkVersion == "" || strings.HasPrefix(sparkVersion, "custom:") {
+		return "", nil
+	}
+	versions, err := w.Clusters.SparkVersions(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range versions.Versions {
+		if v.Key == sparkVersion {
+			return "", nil
+		}
+	}
+	return fmt.Sprintf("spark_version %s is deprecated or no longer supported, "+
+		"please upgrade to one of the supported Databricks Runtime versions", sparkVersion), nil
+}
+
+// sparkVersionAliases are spark_version values that are resolved to the latest matching Databricks Runtime
+// version on cluster creation and update
+var sparkVersionAliases = map[string]compute.SparkVersionRequest{
+	"auto:latest":        {Latest: true, Scala: "2.12"},
+	"auto:latest-lts":    {Latest: true, LongTermSupport: true, Scala: "2.12"},
+	"auto:latest-ml":     {Latest: true, ML: true, Scala: "2.12"},
+	"auto:latest-lts-ml": {Latest: true, LongTermSupport: true, ML: true, Scala: "2.12"},
+}
+
+// resolveSparkVersion returns the concrete Databricks Runtime version for spark_version aliases
+// and the given version as is otherwise
+func resolveSparkVersion(ctx context.Context, w *databricks.WorkspaceClient, sparkVersion string) (string, error) {
+	request, ok := sparkVersionAliases[sparkVersion]
+	if !ok {
+		return sparkVersion, nil
+	}
+	resolved, err := w.Clusters.SelectSparkVersion(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve spark_version %s: %w", sparkVersion, err)
+	}
+	log.Printf("[INFO] Resolved spark_version %s to %s", sparkVersion, resolved)
+	return resolved, nil
+}
+
 type LibraryWithAlias struct {
 	Libraries []compute.Library `json:"libraries,omitempty" tf:"slice_set,alias:library"`
 }
@@ -341,6 +436,14 @@ func (ClusterSpec) CustomizeSchemaResourceSpecific(s *common.CustomizableSchema)
 		Type:     schema.TypeString,
 		Computed: true,
 	})
+	s.AddNewField("spark_context_id", &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	})
+	s.AddNewField("effective_spark_version", &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	})
 	s.AddNewField("url", &schema.Schema{
 		Type:     schema.TypeString,
 		Computed: true,
@@ -429,10 +532,15 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 	if err := Validate(createClusterRequest); err != nil {
 		return err
 	}
+	createClusterRequest.SparkVersion, err = resolveSparkVersion(ctx, w, createClusterRequest.SparkVersion)
+	if err != nil {
+		return err
+	}
 	if err = ModifyRequestOnInstancePool(&createClusterRequest); err != nil {
 		return err
 	}
 	SetForceSendFieldsForCluster(&createClusterRequest, d)
+	warnOnLocalDiskEncryption(ctx, w, createClusterRequest.EnableLocalDiskEncryption, createClusterRequest.NodeTypeId)
 	if createClusterRequest.GcpAttributes != nil {
 		if _, ok := d.GetOkExists("gcp_attributes.0.local_ssd_count"); ok {
 			createClusterRequest.GcpAttributes.ForceSendFields = []string{"LocalSsdCount"}
@@ -515,19 +623,42 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	if err != nil {
 		return wrapMissingClusterError(err, d.Id())
 	}
+	configuredSparkVersion := d.Get("spark_version").(string)
+	configuredSparkConf := d.Get("spark_conf").(map[string]any)
 	if err = common.StructToData(clusterInfo, clusterSchema, d); err != nil {
 		return err
 	}
+	if len(clusterInfo.SparkConf) > 0 {
+		// keep secret references as configured to avoid the diff on formatting
+		d.Set("spark_conf", reconcileSparkConf(configuredSparkConf, clusterInfo.SparkConf))
+	}
+	d.Set("effective_spark_version", clusterInfo.SparkVersion)
+	if _, ok := sparkVersionAliases[configuredSparkVersion]; ok {
+		// keep the alias to avoid the diff against configuration
+		d.Set("spark_version", configuredSparkVersion)
+	}
 	if err = setPinnedStatus(ctx, d, clusterAPI); err != nil {
 		return err
 	}
+	var deprecation error
+	if !d.IsNewResource() {
+		// newly created clusters were just started with a supported version
+		warning, err := sparkVersionDeprecationWarning(ctx, w, clusterInfo.SparkVersion)
+		if err != nil {
+			log.Printf("[WARN] Cannot check if %s is deprecated: %s", clusterInfo.SparkVersion, err)
+		} else if warning != "" {
+			deprecation = common.WarningError{
+				Message: fmt.Sprintf("Cluster %s: %s", d.Id(), warning),
+			}
+		}
+	}
 
 	d.Set("url", c.FormatURL("#setting/clusters/", d.Id(), "/configuration"))
 	shouldSkipLibrariesRead := !common.IsExporter(ctx)
 	if d.Get("library.#").(int) == 0 && shouldSkipLibrariesRead {
 		// don't add externally added libraries, if config has no `library {}` blocks
 		// TODO: check if it still works fine with importing. Perhaps os.Setenv will do the trick
-		return nil
+		return deprecation
 	}
 
 	libsClusterStatus, err := libraries.WaitForLibrariesInstalledSdk(ctx, w, compute.Wait{
@@ -539,9 +670,13 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 		return err
 	}
 	libList := libsClusterStatus.ToLibraryList()
-	return common.StructToData(LibraryWithAlias{
+	err = common.StructToData(LibraryWithAlias{
 		Libraries: libList.Libraries,
 	}, clusterSchema, d)
+	if err != nil {
+		return err
+	}
+	return deprecation
 }
 
 func hasClusterConfigChanged(d *schema.ResourceData) bool {
@@ -574,6 +709,10 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		if err := Validate(cluster); err != nil {
 			return err
 		}
+		cluster.SparkVersion, err = resolveSparkVersion(ctx, w, cluster.SparkVersion)
+		if err != nil {
+			return err
+		}
 		if err = ModifyRequestOnInstancePool(&cluster); err != nil {
 			return err
 		}
@@ -581,6 +720,9 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		if err != nil {
 			return err
 		}
+		if d.HasChanges("enable_local_disk_encryption", "node_type_id") {
+			warnOnLocalDiskEncryption(ctx, w, cluster.EnableLocalDiskEncryption, cluster.NodeTypeId)
+		}
 
 		// We can only call the resize api if the cluster is in the running state
 		// and only the cluster size (ie num_workers OR autoscale) is being changed
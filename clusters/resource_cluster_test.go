@@ -1,6 +1,7 @@
 package clusters
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,6 +83,271 @@ func TestResourceClusterCreate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceClusterCreate_SparkVersionAlias(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/clusters/spark-versions",
+				ReuseRequest: true,
+				Response: compute.GetSparkVersionsResponse{
+					Versions: []compute.SparkVersion{
+						{
+							Key:  "14.3.x-scala2.12",
+							Name: "14.3 LTS (includes Apache Spark 3.5.0, Scala 2.12)",
+						},
+						{
+							Key:  "15.1.x-scala2.12",
+							Name: "15.1 (includes Apache Spark 3.5.0, Scala 2.12)",
+						},
+						{
+							Key:  "14.3.x-cpu-ml-scala2.12",
+							Name: "14.3 LTS ML (includes Apache Spark 3.5.0, Scala 2.12)",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/create",
+				ExpectedRequest: compute.ClusterSpec{
+					NumWorkers:             1,
+					ClusterName:            "Aliased",
+					SparkVersion:           "14.3.x-scala2.12",
+					NodeTypeId:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+				},
+				Response: compute.ClusterDetails{
+					ClusterId: "abc",
+					State:     compute.StateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
+				Response: compute.ClusterDetails{
+					ClusterId:              "abc",
+					NumWorkers:             1,
+					ClusterName:            "Aliased",
+					SparkVersion:           "14.3.x-scala2.12",
+					NodeTypeId:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  compute.StateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/events",
+				ExpectedRequest: compute.GetEvents{
+					ClusterId:  "abc",
+					Limit:      1,
+					Order:      compute.GetEventsOrderDesc,
+					EventTypes: []compute.EventType{compute.EventTypePinned, compute.EventTypeUnpinned},
+				},
+				Response: compute.GetEventsResponse{
+					Events:     []compute.ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Aliased"
+		spark_version = "auto:latest-lts"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "auto:latest-lts", d.Get("spark_version"))
+	assert.Equal(t, "14.3.x-scala2.12", d.Get("effective_spark_version"))
+}
+
+func clusterDriverNodeTypeFixtures(request compute.ClusterSpec, driverNodeTypeId string) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:          "POST",
+			Resource:        "/api/2.1/clusters/create",
+			ExpectedRequest: request,
+			Response: compute.ClusterDetails{
+				ClusterId: "abc",
+				State:     compute.StateRunning,
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/clusters/get?cluster_id=abc",
+			Response: compute.ClusterDetails{
+				ClusterId:              "abc",
+				NumWorkers:             request.NumWorkers,
+				ClusterName:            request.ClusterName,
+				SparkVersion:           request.SparkVersion,
+				NodeTypeId:             request.NodeTypeId,
+				DriverNodeTypeId:       driverNodeTypeId,
+				AutoterminationMinutes: request.AutoterminationMinutes,
+				State:                  compute.StateRunning,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/clusters/events",
+			ExpectedRequest: compute.GetEvents{
+				ClusterId:  "abc",
+				Limit:      1,
+				Order:      compute.GetEventsOrderDesc,
+				EventTypes: []compute.EventType{compute.EventTypePinned, compute.EventTypeUnpinned},
+			},
+			Response: compute.GetEventsResponse{
+				Events:     []compute.ClusterEvent{},
+				TotalCount: 0,
+			},
+		},
+	}
+}
+
+func TestResourceClusterCreate_DistinctDriverNodeType(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: clusterDriverNodeTypeFixtures(compute.ClusterSpec{
+			NumWorkers:             2,
+			ClusterName:            "Big Driver",
+			SparkVersion:           "7.1-scala12",
+			NodeTypeId:             "i3.xlarge",
+			DriverNodeTypeId:       "i3.4xlarge",
+			AutoterminationMinutes: 60,
+		}, "i3.4xlarge"),
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Big Driver"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		driver_node_type_id = "i3.4xlarge"
+		num_workers = 2
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
+	assert.Equal(t, "i3.4xlarge", d.Get("driver_node_type_id"))
+}
+
+func TestResourceClusterCreate_DriverNodeTypeDefaultsToWorker(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: clusterDriverNodeTypeFixtures(compute.ClusterSpec{
+			NumWorkers:             2,
+			ClusterName:            "Same Driver",
+			SparkVersion:           "7.1-scala12",
+			NodeTypeId:             "i3.xlarge",
+			AutoterminationMinutes: 60,
+		}, "i3.xlarge"),
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Same Driver"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 2
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	// driver node type reported by the API is computed, so it doesn't cause a diff against configuration
+	assert.True(t, ResourceCluster().Schema["driver_node_type_id"].Computed)
+	assert.Equal(t, "i3.xlarge", d.Get("driver_node_type_id"))
+}
+
+var sparkVersionsFixture = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/clusters/spark-versions",
+	ReuseRequest: true,
+	Response: compute.GetSparkVersionsResponse{
+		Versions: []compute.SparkVersion{
+			{
+				Key:  "7.1-scala12",
+				Name: "7.1 (includes Apache Spark 3.0.0, Scala 2.12)",
+			},
+		},
+	},
+}
+
+func TestResourceClusterCreate_LocalDiskEncryption(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list-node-types",
+				Response: compute.ListNodeTypesResponse{
+					NodeTypes: []compute.NodeType{
+						{
+							NodeTypeId: "i3.xlarge",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/create",
+				ExpectedRequest: compute.CreateCluster{
+					NumWorkers:                1,
+					ClusterName:               "Encrypted",
+					SparkVersion:              "7.1-scala12",
+					NodeTypeId:                "i3.xlarge",
+					AutoterminationMinutes:    15,
+					EnableLocalDiskEncryption: true,
+				},
+				Response: compute.ClusterDetails{
+					ClusterId: "abc",
+					State:     compute.StateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
+				Response: compute.ClusterDetails{
+					ClusterId:                 "abc",
+					NumWorkers:                1,
+					ClusterName:               "Encrypted",
+					SparkVersion:              "7.1-scala12",
+					NodeTypeId:                "i3.xlarge",
+					AutoterminationMinutes:    15,
+					EnableLocalDiskEncryption: true,
+					State:                     compute.StateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/events",
+				ExpectedRequest: compute.GetEvents{
+					ClusterId:  "abc",
+					Limit:      1,
+					Order:      compute.GetEventsOrderDesc,
+					EventTypes: []compute.EventType{compute.EventTypePinned, compute.EventTypeUnpinned},
+				},
+				Response: compute.GetEventsResponse{
+					Events:     []compute.ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		autotermination_minutes = 15
+		cluster_name = "Encrypted"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		enable_local_disk_encryption = true
+		`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, true, d.Get("enable_local_disk_encryption"))
+}
+
 func TestResourceClusterCreatePinned(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -628,6 +895,7 @@ func TestResourceClusterRead(t *testing.T) {
 					NodeTypeId:             "i3.xlarge",
 					AutoterminationMinutes: 15,
 					State:                  compute.StateRunning,
+					SparkContextId:         123456,
 					Autoscale: &compute.AutoScale{
 						MaxWorkers: 4,
 					},
@@ -660,6 +928,7 @@ func TestResourceClusterRead(t *testing.T) {
 	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
 	assert.Equal(t, 4, d.Get("autoscale.0.max_workers"))
 	assert.Equal(t, "RUNNING", d.Get("state"))
+	assert.Equal(t, 123456, d.Get("spark_context_id"))
 	assert.Equal(t, false, d.Get("is_pinned"))
 
 	for k, v := range d.State().Attributes {
@@ -667,6 +936,110 @@ func TestResourceClusterRead(t *testing.T) {
 	}
 }
 
+func TestResourceClusterRead_SecretSparkConf(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/get?cluster_id=abc",
+				Response: compute.ClusterDetails{
+					ClusterId:    "abc",
+					NumWorkers:   1,
+					ClusterName:  "Shared",
+					SparkVersion: "7.1-scala12",
+					NodeTypeId:   "i3.xlarge",
+					State:        compute.StateRunning,
+					SparkConf: map[string]string{
+						"fs.azure.account.key":         "{{ secrets/storage/key }}",
+						"spark.sql.shuffle.partitions": "200",
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/events",
+				Response: compute.GetEventsResponse{
+					Events: []compute.ClusterEvent{},
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Read:     true,
+		ID:       "abc",
+		New:      true,
+		HCL: `
+		cluster_name = "Shared"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		spark_conf = {
+			"fs.azure.account.key" = "{{secrets/storage/key}}"
+			"spark.sql.shuffle.partitions" = "100"
+		}
+		`,
+		InstanceState: map[string]string{
+			"spark_conf.%":                            "2",
+			"spark_conf.fs.azure.account.key":         "{{secrets/storage/key}}",
+			"spark_conf.spark.sql.shuffle.partitions": "100",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"fs.azure.account.key":         "{{secrets/storage/key}}",
+		"spark.sql.shuffle.partitions": "200",
+	}, d.Get("spark_conf"))
+}
+
+func TestResourceClusterRead_DeprecatedSparkVersion(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/get?cluster_id=abc",
+			Response: compute.ClusterDetails{
+				ClusterId:    "abc",
+				NumWorkers:   1,
+				ClusterName:  "Legacy",
+				SparkVersion: "6.4.x-scala2.11",
+				NodeTypeId:   "i3.xlarge",
+				State:        compute.StateTerminated,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/clusters/events",
+			Response: compute.GetEventsResponse{
+				Events: []compute.ClusterEvent{},
+			},
+		},
+		sparkVersionsFixture,
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		r := ResourceCluster().ToResource()
+		d := r.TestResourceData()
+		d.SetId("abc")
+		diags := r.ReadContext(ctx, d, client)
+		assert.False(t, diags.HasError())
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "Cluster abc: spark_version 6.4.x-scala2.11 is deprecated or no longer supported, "+
+			"please upgrade to one of the supported Databricks Runtime versions", diags[0].Summary)
+		assert.Equal(t, "6.4.x-scala2.11", d.Get("effective_spark_version"))
+	})
+}
+
+func TestReconcileSparkConf_DifferentSecret(t *testing.T) {
+	configured := map[string]any{
+		"fs.azure.account.key": "{{secrets/storage/key}}",
+		"spark.hadoop.token":   "{{secrets/storage/token}}",
+	}
+	assert.Equal(t, map[string]string{
+		"fs.azure.account.key": "{{ secrets/other/key }}",
+		"spark.hadoop.token":   "{{secrets/storage/token}}",
+	}, reconcileSparkConf(configured, map[string]string{
+		"fs.azure.account.key": "{{ secrets/other/key }}",
+		"spark.hadoop.token":   "{{ secrets/storage/token }}",
+	}))
+}
+
 func TestResourceClusterRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -714,6 +1087,7 @@ func TestResourceClusterRead_Error(t *testing.T) {
 func TestResourceClusterUpdate_ResizeForAutoscalingToNumWorkersCluster(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -781,6 +1155,7 @@ func TestResourceClusterUpdate_ResizeForAutoscalingToNumWorkersCluster(t *testin
 func TestResourceClusterUpdate_ResizeForNumWorkersToAutoscalingCluster(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -848,6 +1223,7 @@ func TestResourceClusterUpdate_ResizeForNumWorkersToAutoscalingCluster(t *testin
 func TestResourceClusterUpdate_EditNumWorkersWhenClusterTerminated(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -912,6 +1288,7 @@ func TestResourceClusterUpdate_EditNumWorkersWhenClusterTerminated(t *testing.T)
 func TestResourceClusterUpdate_ResizeAutoscale(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -983,6 +1360,7 @@ func TestResourceClusterUpdate_ResizeAutoscale(t *testing.T) {
 func TestResourceClusterUpdate_ResizeNumWorkers(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -1043,6 +1421,7 @@ func TestResourceClusterUpdate_ResizeNumWorkers(t *testing.T) {
 func TestResourceClusterUpdate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -1123,6 +1502,7 @@ func TestResourceClusterUpdate(t *testing.T) {
 func TestResourceClusterUpdate_WhileScaling(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -1219,6 +1599,7 @@ func TestResourceClusterUpdate_WhileScaling(t *testing.T) {
 func TestResourceClusterUpdateWithPinned(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -1335,6 +1716,7 @@ func TestResourceClusterUpdate_LibrariesChangeOnTerminatedCluster(t *testing.T)
 	}
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			terminated, // 1 of ...
 			{
 				Method:   "POST",
@@ -1502,6 +1884,7 @@ func TestResourceClusterUpdate_Error(t *testing.T) {
 func TestResourceClusterUpdate_AutoAz(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -1753,6 +2136,21 @@ func TestResourceClusterCreate_SingleNodeFail(t *testing.T) {
 	require.Equal(t, true, strings.Contains(err.Error(), "NumWorkers could be 0 only for SingleNode clusters"))
 }
 
+func TestResourceClusterCreate_InvertedAutoscale(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Shared Autoscaling"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		autoscale {
+			min_workers = 5
+			max_workers = 2
+		}`,
+	}.ExpectError(t, "autoscale min_workers (5) must not be greater than max_workers (2)")
+}
+
 func TestResourceClusterCreate_NegativeNumWorkers(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		Create:   true,
@@ -1945,6 +2343,7 @@ func TestRefreshOnRunningClusterWithFailedLibraryUninstallsIt(t *testing.T) {
 func TestResourceClusterUpdate_LocalSsdCount(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
@@ -2019,3 +2418,19 @@ func TestResourceClusterUpdate_LocalSsdCount(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestSparkVersionDeprecationWarning(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{sparkVersionsFixture}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+
+		warning, err := sparkVersionDeprecationWarning(ctx, w, "7.1-scala12")
+		require.NoError(t, err)
+		assert.Equal(t, "", warning)
+
+		warning, err = sparkVersionDeprecationWarning(ctx, w, "6.4.x-scala2.11")
+		require.NoError(t, err)
+		assert.Equal(t, "spark_version 6.4.x-scala2.11 is deprecated or no longer supported, "+
+			"please upgrade to one of the supported Databricks Runtime versions", warning)
+	})
+}
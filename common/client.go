@@ -2,13 +2,17 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/client"
 	"github.com/databricks/databricks-sdk-go/config"
 	"github.com/databricks/databricks-sdk-go/service/iam"
@@ -54,6 +58,12 @@ type DatabricksClient struct {
 	commandFactory        func(context.Context, *DatabricksClient) CommandExecutor
 	cachedWorkspaceClient *databricks.WorkspaceClient
 	cachedAccountClient   *databricks.AccountClient
+	metadataCacheTTL      *time.Duration
+	requestLimiter        chan struct{}
+	hostFailover          *hostFailover
+	defaultNodeTypeID     string
+	defaultSparkVersion   string
+	apiVersionFallback    bool
 	mu                    sync.Mutex
 }
 
@@ -78,10 +88,79 @@ func (c *DatabricksClient) WorkspaceClient() (*databricks.WorkspaceClient, error
 		return nil, err
 	}
 	w.CurrentUser = newCachedMe(w.CurrentUser)
+	ttl := DefaultMetadataCacheTTL
+	if c.metadataCacheTTL != nil {
+		ttl = *c.metadataCacheTTL
+	}
+	w.Clusters = newCachedClusterMetadata(w.Clusters, ttl)
 	c.cachedWorkspaceClient = w
 	return w, nil
 }
 
+// SetMetadataCacheTTL configures for how long Spark versions and node types are cached by
+// the workspace client. Zero TTL disables caching. Has to be called before WorkspaceClient().
+func (c *DatabricksClient) SetMetadataCacheTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metadataCacheTTL = &ttl
+}
+
+// SetMaxConcurrentRequests limits the number of requests the client has in flight at the same time,
+// independently from the rate limit. Zero means unlimited. Has to be called before any requests are made.
+func (c *DatabricksClient) SetMaxConcurrentRequests(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n <= 0 {
+		c.requestLimiter = nil
+		return
+	}
+	c.requestLimiter = make(chan struct{}, n)
+}
+
+// SetClusterDefaults opts into filling the node type and the Spark version of clusters created with
+// ClustersAPI.Create, when the cluster spec leaves them empty. The Spark version may be an alias, like
+// auto:latest-lts. Empty values keep the cluster spec unchanged.
+func (c *DatabricksClient) SetClusterDefaults(nodeTypeID, sparkVersion string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.defaultNodeTypeID = nodeTypeID
+	c.defaultSparkVersion = sparkVersion
+}
+
+// ClusterDefaults returns the node type and the Spark version configured with SetClusterDefaults
+func (c *DatabricksClient) ClusterDefaults() (nodeTypeID, sparkVersion string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.defaultNodeTypeID, c.defaultSparkVersion
+}
+
+// SetApiVersionFallback opts into retrying requests to API 2.0 or 2.1 against the other version, when the
+// workspace reports that the endpoint doesn't exist in the requested one. Has to be called before any
+// requests are made.
+func (c *DatabricksClient) SetApiVersionFallback(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiVersionFallback = enabled
+}
+
+// SetHosts configures ingress endpoints for the same workspace, starting with the primary one. If the client cannot
+// connect to the active host, the retry of the request goes to the next one. A single host keeps requests unchanged.
+// Has to be called before any requests are made.
+func (c *DatabricksClient) SetHosts(hosts []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(hosts) < 2 {
+		c.hostFailover = nil
+		return nil
+	}
+	failover, err := newHostFailover(hosts)
+	if err != nil {
+		return err
+	}
+	c.hostFailover = failover
+	return nil
+}
+
 // Set the cached workspace client.
 func (c *DatabricksClient) SetWorkspaceClient(w *databricks.WorkspaceClient) {
 	c.mu.Lock()
@@ -96,6 +175,13 @@ func (c *DatabricksClient) SetAccountClient(a *databricks.AccountClient) {
 	c.cachedAccountClient = a
 }
 
+// AccountID returns the account ID the client is configured with, or an empty string
+func (c *DatabricksClient) AccountID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.DatabricksClient.Config.AccountID
+}
+
 func (c *DatabricksClient) setAccountId(accountId string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -177,39 +263,77 @@ func (c *DatabricksClient) AccountOrWorkspaceRequest(accCallback func(*databrick
 	}
 }
 
+// do performs the request and, when the context deadline is reached while the
+// request is being retried, returns the context error wrapped with the last API error.
+// Unauthenticated requests return an error that names the authentication method.
+func (c *DatabricksClient) do(ctx context.Context, method, path string,
+	headers map[string]string, request, response any,
+	visitors ...func(*http.Request) error) error {
+	if c.requestLimiter != nil {
+		select {
+		case c.requestLimiter <- struct{}{}:
+			defer func() { <-c.requestLimiter }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if c.hostFailover != nil {
+		visitors = append(visitors, c.hostFailover.visitor())
+	}
+	err := c.Do(ctx, method, path, headers, request, response, visitors...)
+	if err != nil && c.apiVersionFallback && isEndpointNotFound(err) {
+		if current, alternate, ok := alternateApiVersion(ctx); ok {
+			log.Printf("[INFO] %s %s is not available in API %s, retrying with API %s",
+				method, path, current, alternate)
+			err = c.Do(context.WithValue(ctx, Api, alternate), method, path, headers,
+				request, response, visitors...)
+		}
+	}
+	if errors.Is(err, apierr.ErrUnauthenticated) {
+		// rejected credentials are reported with the authentication method and the host,
+		// so that it's clear which of the configured credentials were used
+		err = fmt.Errorf("authentication failed using method=%s for host=%s: %w",
+			c.AuthMethod(), c.Config.Host, err)
+	}
+	if err != nil && ctx.Err() != nil && !errors.Is(err, ctx.Err()) {
+		return fmt.Errorf("%w: %w", ctx.Err(), err)
+	}
+	return err
+}
+
 // Get on path
 func (c *DatabricksClient) Get(ctx context.Context, path string, request any, response any) error {
-	return c.Do(ctx, http.MethodGet, path, nil, request, response, c.addApiPrefix)
+	return c.do(ctx, http.MethodGet, path, nil, request, response, c.addApiPrefix)
 }
 
 // Post on path
 func (c *DatabricksClient) Post(ctx context.Context, path string, request any, response any) error {
-	return c.Do(ctx, http.MethodPost, path, nil, request, response, c.addApiPrefix)
+	return c.do(ctx, http.MethodPost, path, nil, request, response, c.addApiPrefix)
 }
 
 // Delete on path. Ignores succesfull responses from the server.
 func (c *DatabricksClient) Delete(ctx context.Context, path string, request any) error {
-	return c.Do(ctx, http.MethodDelete, path, nil, request, nil, c.addApiPrefix)
+	return c.do(ctx, http.MethodDelete, path, nil, request, nil, c.addApiPrefix)
 }
 
 // Delete on path. Deserializes the response into the response parameter.
 func (c *DatabricksClient) DeleteWithResponse(ctx context.Context, path string, request any, response any) error {
-	return c.Do(ctx, http.MethodDelete, path, nil, request, response, c.addApiPrefix)
+	return c.do(ctx, http.MethodDelete, path, nil, request, response, c.addApiPrefix)
 }
 
 // Patch on path. Ignores succesfull responses from the server.
 func (c *DatabricksClient) Patch(ctx context.Context, path string, request any) error {
-	return c.Do(ctx, http.MethodPatch, path, nil, request, nil, c.addApiPrefix)
+	return c.do(ctx, http.MethodPatch, path, nil, request, nil, c.addApiPrefix)
 }
 
 // Patch on path. Deserializes the response into the response parameter.
 func (c *DatabricksClient) PatchWithResponse(ctx context.Context, path string, request any, response any) error {
-	return c.Do(ctx, http.MethodPatch, path, nil, request, response, c.addApiPrefix)
+	return c.do(ctx, http.MethodPatch, path, nil, request, response, c.addApiPrefix)
 }
 
 // Put on path
 func (c *DatabricksClient) Put(ctx context.Context, path string, request any) error {
-	return c.Do(ctx, http.MethodPut, path, nil, request, nil, c.addApiPrefix)
+	return c.do(ctx, http.MethodPut, path, nil, request, nil, c.addApiPrefix)
 }
 
 type ApiVersion string
@@ -220,6 +344,29 @@ const (
 	API_2_1 ApiVersion = "2.1"
 )
 
+// isEndpointNotFound returns true if the workspace doesn't have the requested endpoint, as opposed to
+// the requested entity not being found
+func isEndpointNotFound(err error) bool {
+	var apiErr *apierr.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode == "ENDPOINT_NOT_FOUND"
+}
+
+// alternateApiVersion returns the API version requested in the context and the one to fall back to
+func alternateApiVersion(ctx context.Context) (ApiVersion, ApiVersion, bool) {
+	current, ok := ctx.Value(Api).(ApiVersion)
+	if !ok {
+		current = API_2_0
+	}
+	switch current {
+	case API_2_0:
+		return current, API_2_1, true
+	case API_2_1:
+		return current, API_2_0, true
+	default:
+		return current, "", false
+	}
+}
+
 func (c *DatabricksClient) addApiPrefix(r *http.Request) error {
 	if r.URL == nil {
 		return fmt.Errorf("no URL found in request")
@@ -246,11 +393,20 @@ func (c *DatabricksClient) scimVisitor(r *http.Request) error {
 
 // Scim sets SCIM headers
 func (c *DatabricksClient) Scim(ctx context.Context, method, path string, request any, response any) error {
-	return c.Do(ctx, method, path, map[string]string{
+	return c.do(ctx, method, path, map[string]string{
 		"Content-Type": "application/scim+json; charset=utf-8",
 	}, request, response, c.addApiPrefix, c.scimVisitor)
 }
 
+// AuthMethod returns the name of the authentication method the client resolved during configuration,
+// like pat, azure-client-secret or databricks-cli, or unknown if the client isn't authenticated yet
+func (c *DatabricksClient) AuthMethod() string {
+	if c.Config.AuthType == "" {
+		return "unknown"
+	}
+	return c.Config.AuthType
+}
+
 // IsAzure returns true if client is configured for Azure Databricks - either by using AAD auth or with host+token combination
 func (c *DatabricksClient) IsAzure() bool {
 	return c.Config.IsAzure()
@@ -276,6 +432,31 @@ func (c *DatabricksClient) FormatURL(strs ...string) string {
 	return strings.Join(data, "")
 }
 
+// orgIDInHost matches workspace hosts that carry the organization ID, like adb-123.4.azuredatabricks.net
+// on Azure or 123.4.gcp.databricks.com on GCP
+var orgIDInHost = regexp.MustCompile(`^(?:adb-)?(\d+)\.\d+\.(?:azuredatabricks\.net|gcp\.databricks\.com)$`)
+
+// WorkspaceURL creates a link to the workspace page from the client Host and the path relative to it,
+// like "#notebook/123". On workspaces that are identified by the organization ID, the `?o=` parameter
+// is added before the URL fragment, so that the link opens the right workspace.
+func (c *DatabricksClient) WorkspaceURL(relativePath string) string {
+	host := strings.TrimSuffix(c.Config.Host, "/")
+	path, fragment, hasFragment := strings.Cut(strings.TrimPrefix(relativePath, "/"), "#")
+	hostname := strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+	if m := orgIDInHost.FindStringSubmatch(hostname); m != nil {
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		path += separator + "o=" + m[1]
+	}
+	url := host + "/" + path
+	if hasFragment {
+		url += "#" + fragment
+	}
+	return url
+}
+
 // ClientForHost creates a new DatabricksClient instance with the same auth parameters,
 // but for the given host. Authentication has to be reinitialized, as Google OIDC has
 // different authorizers, depending if it's workspace or Accounts API we're talking to.
@@ -2,12 +2,18 @@ package common
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/client"
 	"github.com/databricks/databricks-sdk-go/config"
 	"github.com/databricks/databricks-sdk-go/service/iam"
@@ -194,6 +200,30 @@ func TestDatabricksClient_FormatURL(t *testing.T) {
 	assert.Equal(t, "https://some.host/#job/123", client.FormatURL("#job/123"))
 }
 
+func TestDatabricksClient_WorkspaceURL(t *testing.T) {
+	newClient := func(host string) DatabricksClient {
+		return DatabricksClient{
+			DatabricksClient: &client.DatabricksClient{
+				Config: &config.Config{
+					Host: host,
+				},
+			},
+		}
+	}
+	c := newClient("https://some.host/")
+	assert.Equal(t, "https://some.host/#notebook/123", c.WorkspaceURL("#notebook/123"))
+
+	c = newClient("https://adb-1234567890.12.azuredatabricks.net")
+	assert.Equal(t, "https://adb-1234567890.12.azuredatabricks.net/?o=1234567890#notebook/123",
+		c.WorkspaceURL("#notebook/123"))
+	assert.Equal(t, "https://adb-1234567890.12.azuredatabricks.net/sql/editor?id=1&o=1234567890",
+		c.WorkspaceURL("/sql/editor?id=1"))
+
+	c = newClient("https://987.6.gcp.databricks.com")
+	assert.Equal(t, "https://987.6.gcp.databricks.com/?o=987#workspace/Users/me/notebook",
+		c.WorkspaceURL("#workspace/Users/me/notebook"))
+}
+
 func TestDatabricksIsGcp(t *testing.T) {
 	dc, err := configureAndAuthenticate(&DatabricksClient{
 		DatabricksClient: &client.DatabricksClient{
@@ -335,3 +365,140 @@ func TestCachedMe_Me_MakesSingleRequest(t *testing.T) {
 	cm.Me(context.Background())
 	assert.Equal(t, 1, mock.count)
 }
+
+func TestDatabricksClient_DeadlineExceededDuringRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		rw.Write([]byte(`{"error_code": "TEMPORARILY_UNAVAILABLE", "message": "try again later"}`))
+	}))
+	defer server.Close()
+	c, err := client.New(&config.Config{
+		Host:  server.URL,
+		Token: "x",
+	})
+	require.NoError(t, err)
+	dc := &DatabricksClient{DatabricksClient: c}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = dc.Get(ctx, "/clusters/get", nil, nil)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "try again later")
+}
+
+func TestDatabricksClient_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	c, err := client.New(&config.Config{
+		Host:               server.URL,
+		Token:              "x",
+		RateLimitPerSecond: 100,
+	})
+	require.NoError(t, err)
+	dc := &DatabricksClient{DatabricksClient: c}
+	dc.SetMaxConcurrentRequests(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, dc.Get(context.Background(), "/clusters/get", nil, nil))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), maxInFlight.Load())
+}
+
+func TestDatabricksClient_UnauthenticatedNamesAuthMethod(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		rw.Write([]byte(`{"error_code": "UNAUTHENTICATED", "message": "Invalid access token"}`))
+	}))
+	defer server.Close()
+	c, err := client.New(&config.Config{
+		Host:  server.URL,
+		Token: "x",
+	})
+	require.NoError(t, err)
+	dc := &DatabricksClient{DatabricksClient: c}
+	assert.Equal(t, "unknown", dc.AuthMethod())
+
+	err = dc.Get(context.Background(), "/clusters/get", nil, nil)
+	assert.EqualError(t, err, fmt.Sprintf(
+		"authentication failed using method=pat for host=%s: Invalid access token", server.URL))
+	assert.ErrorIs(t, err, apierr.ErrUnauthenticated)
+	assert.Equal(t, "pat", dc.AuthMethod())
+}
+
+func TestDatabricksClient_PermissionDeniedIsNotWrapped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+		rw.Write([]byte(`{"error_code": "PERMISSION_DENIED", "message": "No access to cluster"}`))
+	}))
+	defer server.Close()
+	c, err := client.New(&config.Config{
+		Host:  server.URL,
+		Token: "x",
+	})
+	require.NoError(t, err)
+	dc := &DatabricksClient{DatabricksClient: c}
+	err = dc.Get(context.Background(), "/clusters/get", nil, nil)
+	assert.EqualError(t, err, "No access to cluster")
+	assert.ErrorIs(t, err, apierr.ErrPermissionDenied)
+}
+
+func apiVersionFallbackServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/2.1/jobs/get":
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"error_code": "ENDPOINT_NOT_FOUND", "message": "No API found for 'GET /jobs/get'"}`))
+		case "/api/2.0/jobs/get":
+			rw.Write([]byte(`{"job_id": 123}`))
+		case "/api/2.1/jobs/runs/get":
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"error_code": "RESOURCE_DOES_NOT_EXIST", "message": "Run 456 does not exist."}`))
+		default:
+			t.Errorf("unexpected request: %s", req.URL.Path)
+		}
+	}))
+}
+
+func TestDatabricksClient_ApiVersionFallback(t *testing.T) {
+	server := apiVersionFallbackServer(t)
+	defer server.Close()
+	c, err := client.New(&config.Config{
+		Host:  server.URL,
+		Token: "x",
+	})
+	require.NoError(t, err)
+	dc := &DatabricksClient{DatabricksClient: c}
+	ctx := context.WithValue(context.Background(), Api, API_2_1)
+
+	var job map[string]any
+	err = dc.Get(ctx, "/jobs/get", nil, &job)
+	assert.True(t, isEndpointNotFound(err), "%v", err)
+
+	dc.SetApiVersionFallback(true)
+	err = dc.Get(ctx, "/jobs/get", nil, &job)
+	require.NoError(t, err)
+	assert.Equal(t, float64(123), job["job_id"])
+
+	err = dc.Get(ctx, "/jobs/runs/get", nil, &job)
+	assert.ErrorIs(t, err, apierr.ErrResourceDoesNotExist)
+}
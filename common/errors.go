@@ -0,0 +1,26 @@
+package common
+
+import "fmt"
+
+// NotSupportedError is returned when the requested functionality is not exposed
+// by the API, so that callers can degrade gracefully by checking it with errors.As
+type NotSupportedError struct {
+	Feature string
+	Reason  string
+}
+
+func (e NotSupportedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%s is not supported", e.Feature)
+	}
+	return fmt.Sprintf("%s is not supported: %s", e.Feature, e.Reason)
+}
+
+// WarningError is returned by Read of a resource to report a warning diagnostic without failing the read
+type WarningError struct {
+	Message string
+}
+
+func (e WarningError) Error() string {
+	return e.Message
+}
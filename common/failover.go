@@ -0,0 +1,96 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"sync/atomic"
+)
+
+// hostFailover rewrites requests to the currently active host and switches to the next one,
+// when an attempt cannot connect to the active host, so that the retry goes there
+type hostFailover struct {
+	hosts  []*url.URL
+	active atomic.Int32
+}
+
+func newHostFailover(hosts []string) (*hostFailover, error) {
+	f := &hostFailover{}
+	for _, host := range hosts {
+		u, err := url.Parse(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host %s: %w", host, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid host %s: scheme and hostname are required", host)
+		}
+		f.hosts = append(f.hosts, u)
+	}
+	return f, nil
+}
+
+// visitor returns the request visitor for all attempts of a single call. Every attempt goes to the active host.
+// If the previous attempt couldn't get a connection because dialing failed, the active host is switched to the
+// next one before the retry. Failed dials of an attempt that still gets a connection, like the ones that lose
+// the race between IPv4 and IPv6 addresses, don't switch hosts.
+func (f *hostFailover) visitor() func(r *http.Request) error {
+	var previous *failoverAttempt
+	return func(r *http.Request) error {
+		if previous != nil {
+			f.failover(previous)
+		}
+		attempt := &failoverAttempt{idx: f.active.Load()}
+		previous = attempt
+		host := f.hosts[attempt.idx]
+		r.URL.Scheme = host.Scheme
+		r.URL.Host = host.Host
+		r.Host = ""
+		trace := &httptrace.ClientTrace{
+			ConnectDone: func(network, addr string, err error) {
+				if err != nil {
+					attempt.setDialErr(err)
+				}
+			},
+			GotConn: func(httptrace.GotConnInfo) {
+				attempt.connected.Store(true)
+			},
+		}
+		*r = *r.WithContext(httptrace.WithClientTrace(r.Context(), trace))
+		return nil
+	}
+}
+
+func (f *hostFailover) failover(attempt *failoverAttempt) {
+	err := attempt.dialErr()
+	if attempt.connected.Load() || err == nil {
+		return
+	}
+	next := (attempt.idx + 1) % int32(len(f.hosts))
+	if f.active.CompareAndSwap(attempt.idx, next) {
+		log.Printf("[WARN] Cannot connect to %s, failing over to %s: %s",
+			f.hosts[attempt.idx].Host, f.hosts[next].Host, err)
+	}
+}
+
+// failoverAttempt tracks whether a single attempt of the request got a connection to the host
+type failoverAttempt struct {
+	idx       int32
+	connected atomic.Bool
+	mu        sync.Mutex
+	err       error
+}
+
+func (a *failoverAttempt) setDialErr(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.err = err
+}
+
+func (a *failoverAttempt) dialErr() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
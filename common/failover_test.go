@@ -0,0 +1,93 @@
+package common
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabricksClient_HostFailover(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	refusing := "http://" + listener.Addr().String()
+	listener.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/api/2.0/clusters/get", req.URL.Path)
+		rw.Write([]byte(`{"cluster_id": "abc"}`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(&config.Config{
+		Host:  refusing,
+		Token: "x",
+	})
+	require.NoError(t, err)
+	dc := &DatabricksClient{DatabricksClient: c}
+	err = dc.SetHosts([]string{refusing, server.URL})
+	require.NoError(t, err)
+
+	var response map[string]string
+	err = dc.Get(context.Background(), "/clusters/get", nil, &response)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", response["cluster_id"])
+}
+
+// lostDialTransport reports a failed dial before every round trip, like the one of an address that
+// loses the race between IPv4 and IPv6, and then makes the request over the default transport
+type lostDialTransport struct{}
+
+func (lostDialTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if trace := httptrace.ContextClientTrace(r.Context()); trace != nil && trace.ConnectDone != nil {
+		trace.ConnectDone("tcp", "[::1]:443", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED})
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestDatabricksClient_HostFailoverIgnoresLostDial(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if requests.Add(1) == 1 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			rw.Write([]byte(`{"error_code": "REQUEST_LIMIT_EXCEEDED", "message": "Slow down"}`))
+			return
+		}
+		rw.Write([]byte(`{"cluster_id": "abc"}`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(&config.Config{
+		Host:          server.URL,
+		Token:         "x",
+		HTTPTransport: lostDialTransport{},
+	})
+	require.NoError(t, err)
+	dc := &DatabricksClient{DatabricksClient: c}
+	err = dc.SetHosts([]string{server.URL, "http://secondary.cloud.databricks.com"})
+	require.NoError(t, err)
+
+	var response map[string]string
+	err = dc.Get(context.Background(), "/clusters/get", nil, &response)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", response["cluster_id"])
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(0), dc.hostFailover.active.Load())
+}
+
+func TestDatabricksClient_SetHostsInvalid(t *testing.T) {
+	dc := &DatabricksClient{}
+	assert.NoError(t, dc.SetHosts([]string{"https://single.cloud.databricks.com"}))
+	assert.Nil(t, dc.hostFailover)
+	assert.EqualError(t, dc.SetHosts([]string{"https://a.cloud.databricks.com", "b"}),
+		"invalid host b: scheme and hostname are required")
+}
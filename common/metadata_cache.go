@@ -0,0 +1,65 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+)
+
+// DefaultMetadataCacheTTL is how long responses of rarely changing metadata endpoints,
+// like the list of Spark versions or node types, are reused
+const DefaultMetadataCacheTTL = 5 * time.Minute
+
+type cachedEntry[T any] struct {
+	value   *T
+	expires time.Time
+}
+
+// cachedClusterMetadata caches Spark versions and node types, delegating all other calls
+type cachedClusterMetadata struct {
+	compute.ClustersInterface
+
+	ttl           time.Duration
+	now           func() time.Time
+	mu            sync.Mutex
+	sparkVersions cachedEntry[compute.GetSparkVersionsResponse]
+	nodeTypes     cachedEntry[compute.ListNodeTypesResponse]
+}
+
+func newCachedClusterMetadata(inner compute.ClustersInterface, ttl time.Duration) *cachedClusterMetadata {
+	return &cachedClusterMetadata{
+		ClustersInterface: inner,
+		ttl:               ttl,
+		now:               time.Now,
+	}
+}
+
+func getCached[T any](ctx context.Context, c *cachedClusterMetadata, entry *cachedEntry[T],
+	fetch func(context.Context) (*T, error)) (*T, error) {
+	skip, _ := ctx.Value(SkipMetadataCache).(bool)
+	if c.ttl <= 0 || skip {
+		return fetch(ctx)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry.value != nil && c.now().Before(entry.expires) {
+		return entry.value, nil
+	}
+	v, err := fetch(ctx)
+	if err != nil {
+		return v, err
+	}
+	entry.value = v
+	entry.expires = c.now().Add(c.ttl)
+	return v, nil
+}
+
+func (c *cachedClusterMetadata) SparkVersions(ctx context.Context) (*compute.GetSparkVersionsResponse, error) {
+	return getCached(ctx, c, &c.sparkVersions, c.ClustersInterface.SparkVersions)
+}
+
+func (c *cachedClusterMetadata) ListNodeTypes(ctx context.Context) (*compute.ListNodeTypesResponse, error) {
+	return getCached(ctx, c, &c.nodeTypes, c.ClustersInterface.ListNodeTypes)
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedClusterMetadata_SparkVersions(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/api/2.1/clusters/spark-versions", req.URL.Path)
+		hits++
+		rw.Write([]byte(`{"versions": [{"key": "15.4.x-scala2.12", "name": "15.4 LTS"}]}`))
+	}))
+	defer server.Close()
+	c, err := client.New(&config.Config{
+		Host:  server.URL,
+		Token: "x",
+	})
+	require.NoError(t, err)
+	w, err := (&DatabricksClient{DatabricksClient: c}).WorkspaceClient()
+	require.NoError(t, err)
+	cache := w.Clusters.(*cachedClusterMetadata)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	versions, err := w.Clusters.SparkVersions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "15.4.x-scala2.12", versions.Versions[0].Key)
+	_, err = w.Clusters.SparkVersions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, hits)
+
+	_, err = w.Clusters.SparkVersions(context.WithValue(ctx, SkipMetadataCache, true))
+	require.NoError(t, err)
+	assert.Equal(t, 2, hits)
+
+	now = now.Add(DefaultMetadataCacheTTL + time.Second)
+	_, err = w.Clusters.SparkVersions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, hits)
+}
+
+func TestCachedClusterMetadata_Disabled(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		hits++
+		rw.Write([]byte(`{"node_types": []}`))
+	}))
+	defer server.Close()
+	c, err := client.New(&config.Config{
+		Host:  server.URL,
+		Token: "x",
+	})
+	require.NoError(t, err)
+	dc := &DatabricksClient{DatabricksClient: c}
+	dc.SetMetadataCacheTTL(0)
+	w, err := dc.WorkspaceClient()
+	require.NoError(t, err)
+	ctx := context.Background()
+	_, err = w.Clusters.ListNodeTypes(ctx)
+	require.NoError(t, err)
+	_, err = w.Clusters.ListNodeTypes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, hits)
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
@@ -38,6 +39,20 @@ func nicerError(ctx context.Context, err error, action string) error {
 		strings.ReplaceAll(name, "_", " "), err)
 }
 
+// readDiagnostics converts the error of Read into diagnostics, reporting WarningError as a warning
+func readDiagnostics(ctx context.Context, err error) diag.Diagnostics {
+	var warning WarningError
+	if errors.As(err, &warning) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  warning.Message,
+			},
+		}
+	}
+	return diag.FromErr(nicerError(ctx, err, "read"))
+}
+
 func recoverable(cb func(
 	ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error) func(
 	ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
@@ -95,8 +110,7 @@ func (r Resource) ToResource() *schema.Resource {
 				return diag.FromErr(err)
 			}
 			if err := recoverable(r.Read)(ctx, d, c); err != nil {
-				err = nicerError(ctx, err, "read")
-				return diag.FromErr(err)
+				return readDiagnostics(ctx, err)
 			}
 			return nil
 		}
@@ -137,8 +151,7 @@ func (r Resource) ToResource() *schema.Resource {
 				return nil
 			}
 			if err != nil {
-				err = nicerError(ctx, err, "read")
-				return diag.FromErr(err)
+				return readDiagnostics(ctx, err)
 			}
 			return nil
 		}
@@ -163,8 +176,7 @@ func (r Resource) ToResource() *schema.Resource {
 				return diag.FromErr(err)
 			}
 			if err = recoverable(r.Read)(ctx, d, c); err != nil {
-				err = nicerError(ctx, err, "read")
-				return diag.FromErr(err)
+				return readDiagnostics(ctx, err)
 			}
 			return nil
 		}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -126,6 +127,33 @@ func TestUpdate(t *testing.T) {
 	assert.Equal(t, "nope", diags[0].Summary)
 }
 
+func TestReadWarning(t *testing.T) {
+	r := Resource{
+		Read: func(ctx context.Context,
+			d *schema.ResourceData,
+			c *DatabricksClient) error {
+			d.Set("foo", 1)
+			return WarningError{Message: "foo is deprecated"}
+		},
+		Schema: map[string]*schema.Schema{
+			"foo": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+		},
+	}.ToResource()
+
+	d := r.TestResourceData()
+	d.SetId("abc")
+	diags := r.ReadContext(context.Background(), d, &DatabricksClient{})
+	assert.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "foo is deprecated", diags[0].Summary)
+	assert.Equal(t, 1, d.Get("foo"))
+	assert.Equal(t, "abc", d.Id())
+}
+
 func TestRecoverableFromPanic(t *testing.T) {
 	r := Resource{
 		Update: func(ctx context.Context,
@@ -14,6 +14,8 @@ var (
 	IsData contextKey = 4
 	// apiVersion
 	Api contextKey = 5
+	// If cached metadata, like Spark versions and node types, should be fetched again
+	SkipMetadataCache contextKey = 6
 )
 
 type contextKey int
@@ -100,7 +100,7 @@ func TestImportingMounts(t *testing.T) {
 			{
 				Method:       "GET",
 				ReuseRequest: true,
-				Resource:     "/api/2.0/clusters/list",
+				Resource:     "/api/2.1/clusters/list",
 				Response: clusters.ClusterList{
 					Clusters: []clusters.ClusterInfo{
 						{
@@ -664,7 +664,7 @@ func TestImportingUsersGroupsSecretScopes(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list",
 				Response: clusters.ClusterList{},
 			},
 			{
@@ -795,7 +795,7 @@ func TestImportingNoResourcesError(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list",
 				Response: clusters.ClusterList{},
 			},
 			{
@@ -839,7 +839,7 @@ func TestImportingClusters(t *testing.T) {
 			},
 			{
 				Method:       "GET",
-				Resource:     "/api/2.0/clusters/list",
+				Resource:     "/api/2.1/clusters/list",
 				Response:     getJSONObject("test-data/clusters-list-response.json"),
 				ReuseRequest: true,
 			},
@@ -1582,7 +1582,7 @@ func TestImportingSecrets(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list",
 				Response: clusters.ClusterList{},
 			},
 			{
@@ -400,7 +400,7 @@ func TestClusterListFails(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/list",
+			Resource: "/api/2.1/clusters/list",
 			Status:   404,
 			Response: apierr.NotFound("nope"),
 		},
@@ -415,7 +415,7 @@ func TestClusterList_NoNameMatch(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/list",
+			Resource: "/api/2.1/clusters/list",
 			Response: clusters.ClusterList{
 				Clusters: []clusters.ClusterInfo{
 					{
@@ -0,0 +1,151 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/jobs"
+	"github.com/databricks/terraform-provider-databricks/pools"
+	"github.com/databricks/terraform-provider-databricks/workspace"
+)
+
+// Kinds of objects included in the workspace inventory
+const (
+	InventoryClusters        = "clusters"
+	InventoryJobs            = "jobs"
+	InventoryInstancePools   = "instance_pools"
+	InventoryClusterPolicies = "cluster_policies"
+	InventorySecretScopes    = "secret_scopes"
+	InventoryNotebooks       = "notebooks"
+)
+
+// InventoryOptions configures ExportInventory
+type InventoryOptions struct {
+	// Kinds limits the inventory to the given kinds of objects, all kinds are included by default
+	Kinds []string
+	// NotebooksPath is the folder listed recursively for notebooks, / by default
+	NotebooksPath string
+}
+
+// InventoryObject identifies a single object in the workspace
+type InventoryObject struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	// Type is the backend type of secret scopes
+	Type string `json:"type,omitempty"`
+}
+
+// WorkspaceInventory contains the objects managed by the provider, to be compared with the Terraform state
+type WorkspaceInventory struct {
+	Clusters        []InventoryObject `json:"clusters,omitempty"`
+	Jobs            []InventoryObject `json:"jobs,omitempty"`
+	InstancePools   []InventoryObject `json:"instance_pools,omitempty"`
+	ClusterPolicies []InventoryObject `json:"cluster_policies,omitempty"`
+	// SecretScopes contain only scope names and backend types, but never secrets
+	SecretScopes []InventoryObject `json:"secret_scopes,omitempty"`
+	Notebooks    []string          `json:"notebooks,omitempty"`
+	// Errors maps kinds of objects, that couldn't be listed, to the error message
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// ExportInventory lists clusters, jobs, instance pools, cluster policies, secret scopes and notebooks of the
+// workspace. Kinds are listed one after another through the client, so that its rate limits apply. Failures
+// to list a kind don't stop the rest: they are recorded in the inventory and returned joined in the error.
+func ExportInventory(ctx context.Context, c *common.DatabricksClient, opts InventoryOptions) (WorkspaceInventory, error) {
+	inventory := WorkspaceInventory{}
+	w, err := c.WorkspaceClient()
+	if err != nil {
+		return inventory, err
+	}
+	notebooksPath := opts.NotebooksPath
+	if notebooksPath == "" {
+		notebooksPath = "/"
+	}
+	collectors := []struct {
+		kind    string
+		collect func() error
+	}{
+		{InventoryClusters, func() error {
+			all, err := clusters.NewClustersAPI(ctx, c).List()
+			for _, cluster := range all {
+				inventory.Clusters = append(inventory.Clusters, InventoryObject{
+					ID:   cluster.ClusterID,
+					Name: cluster.ClusterName,
+				})
+			}
+			return err
+		}},
+		{InventoryJobs, func() error {
+			all, err := jobs.NewJobsAPI(ctx, c).List()
+			for _, job := range all {
+				object := InventoryObject{ID: strconv.FormatInt(job.JobID, 10)}
+				if job.Settings != nil {
+					object.Name = job.Settings.Name
+				}
+				inventory.Jobs = append(inventory.Jobs, object)
+			}
+			return err
+		}},
+		{InventoryInstancePools, func() error {
+			all, err := pools.NewInstancePoolsAPI(ctx, c).List()
+			for _, pool := range all.InstancePools {
+				inventory.InstancePools = append(inventory.InstancePools, InventoryObject{
+					ID:   pool.InstancePoolID,
+					Name: pool.InstancePoolName,
+				})
+			}
+			return err
+		}},
+		{InventoryClusterPolicies, func() error {
+			all, err := w.ClusterPolicies.ListAll(ctx, compute.ListClusterPoliciesRequest{})
+			for _, policy := range all {
+				inventory.ClusterPolicies = append(inventory.ClusterPolicies, InventoryObject{
+					ID:   policy.PolicyId,
+					Name: policy.Name,
+				})
+			}
+			return err
+		}},
+		{InventorySecretScopes, func() error {
+			all, err := w.Secrets.ListScopesAll(ctx)
+			for _, scope := range all {
+				inventory.SecretScopes = append(inventory.SecretScopes, InventoryObject{
+					ID:   scope.Name,
+					Name: scope.Name,
+					Type: string(scope.BackendType),
+				})
+			}
+			return err
+		}},
+		{InventoryNotebooks, func() error {
+			all, err := workspace.NewNotebooksAPI(ctx, c).List(notebooksPath, true, false)
+			for _, object := range all {
+				if object.ObjectType == workspace.Notebook {
+					inventory.Notebooks = append(inventory.Notebooks, object.Path)
+				}
+			}
+			return err
+		}},
+	}
+	var errs []error
+	for _, collector := range collectors {
+		if len(opts.Kinds) > 0 && !slices.Contains(opts.Kinds, collector.kind) {
+			continue
+		}
+		err := collector.collect()
+		if err != nil {
+			if inventory.Errors == nil {
+				inventory.Errors = map[string]string{}
+			}
+			inventory.Errors[collector.kind] = err.Error()
+			errs = append(errs, fmt.Errorf("cannot list %s: %w", collector.kind, err))
+		}
+	}
+	return inventory, errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/jobs"
+	"github.com/databricks/terraform-provider-databricks/qa"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var inventoryClustersList = qa.HTTPFixture{
+	Method:   "GET",
+	Resource: "/api/2.1/clusters/list",
+	Response: clusters.ClusterList{
+		Clusters: []clusters.ClusterInfo{
+			{
+				ClusterID:   "abc",
+				ClusterName: "Shared",
+			},
+		},
+	},
+}
+
+func TestExportInventory(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		inventoryClustersList,
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/list?expand_tasks=false&limit=25",
+			Response: jobs.JobListResponse{
+				Jobs: []jobs.Job{
+					{
+						JobID: 123,
+						Settings: &jobs.JobSettings{
+							Name: "Nightly",
+						},
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		inventory, err := ExportInventory(ctx, client, InventoryOptions{
+			Kinds: []string{InventoryClusters, InventoryJobs},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, WorkspaceInventory{
+			Clusters: []InventoryObject{
+				{ID: "abc", Name: "Shared"},
+			},
+			Jobs: []InventoryObject{
+				{ID: "123", Name: "Nightly"},
+			},
+		}, inventory)
+	})
+}
+
+func TestExportInventory_PartialFailure(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		inventoryClustersList,
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/list?expand_tasks=false&limit=25",
+			Response: common.APIErrorBody{
+				ErrorCode: "PERMISSION_DENIED",
+				Message:   "Only admins can list all jobs",
+			},
+			Status: 403,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		inventory, err := ExportInventory(ctx, client, InventoryOptions{
+			Kinds: []string{InventoryClusters, InventoryJobs},
+		})
+		assert.EqualError(t, err, "cannot list jobs: Only admins can list all jobs")
+		assert.Equal(t, []InventoryObject{{ID: "abc", Name: "Shared"}}, inventory.Clusters)
+		assert.Equal(t, map[string]string{
+			InventoryJobs: "Only admins can list all jobs",
+		}, inventory.Errors)
+	})
+}
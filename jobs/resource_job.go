@@ -2,9 +2,11 @@ package jobs
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,6 +23,7 @@ import (
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/clusters"
 	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/permissions"
 	"github.com/databricks/terraform-provider-databricks/repos"
 )
 
@@ -382,8 +385,9 @@ func (j Job) ID() string {
 
 // RunParameters used to pass params to tasks
 type RunParameters struct {
-	// a shortcut field to reuse this type for RunNow
-	JobID int64 `json:"job_id,omitempty"`
+	// shortcut fields to reuse this type for RunNow
+	JobID            int64  `json:"job_id,omitempty"`
+	IdempotencyToken string `json:"idempotency_token,omitempty"`
 
 	NotebookParams    map[string]string `json:"notebook_params,omitempty"`
 	JarParams         []string          `json:"jar_params,omitempty"`
@@ -411,12 +415,117 @@ type JobRun struct {
 	RunID       int64    `json:"run_id,omitempty"`
 	NumberInJob int64    `json:"number_in_job,omitempty"`
 	StartTime   int64    `json:"start_time,omitempty"`
+	EndTime     int64    `json:"end_time,omitempty"`
 	State       RunState `json:"state,omitempty"`
 	Trigger     string   `json:"trigger,omitempty"`
 	RuntType    string   `json:"run_type,omitempty"`
 
+	// durations of run phases in milliseconds
+	SetupDuration     int64 `json:"setup_duration,omitempty"`
+	ExecutionDuration int64 `json:"execution_duration,omitempty"`
+	CleanupDuration   int64 `json:"cleanup_duration,omitempty"`
+	QueueDuration     int64 `json:"queue_duration,omitempty"`
+	// RunDuration is reported for multi-task runs instead of the phase durations
+	RunDuration int64 `json:"run_duration,omitempty"`
+
 	OverridingParameters RunParameters  `json:"overriding_parameters,omitempty"`
 	JobParameters        []JobParameter `json:"job_parameters,omitempty"`
+
+	Tasks         []RunTask           `json:"tasks,omitempty"`
+	RepairHistory []RepairHistoryItem `json:"repair_history,omitempty"`
+}
+
+// QueueDuration returns for how long the run waited before the setup has started. If the API doesn't
+// report it, it's derived from the difference between total run time and durations of its phases, or
+// the run duration of multi-task runs. Zero is returned if neither of them is reported.
+func QueueDuration(run JobRun) time.Duration {
+	if run.QueueDuration > 0 {
+		return time.Duration(run.QueueDuration) * time.Millisecond
+	}
+	if run.StartTime == 0 || run.EndTime == 0 {
+		return 0
+	}
+	active := run.SetupDuration + run.ExecutionDuration + run.CleanupDuration
+	if active == 0 {
+		active = run.RunDuration
+	}
+	if active == 0 {
+		return 0
+	}
+	queued := run.EndTime - run.StartTime - active
+	if queued < 0 {
+		return 0
+	}
+	return time.Duration(queued) * time.Millisecond
+}
+
+// NotebookOutput is the value passed to dbutils.notebook.exit() by a notebook task
+type NotebookOutput struct {
+	Result string `json:"result,omitempty"`
+	// Truncated is true if the result exceeded the size limit and only its beginning is returned
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// RunOutput is the output of a single task run
+type RunOutput struct {
+	Metadata       *JobRun         `json:"metadata,omitempty"`
+	NotebookOutput *NotebookOutput `json:"notebook_output,omitempty"`
+	Logs           string          `json:"logs,omitempty"`
+	LogsTruncated  bool            `json:"logs_truncated,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	ErrorTrace     string          `json:"error_trace,omitempty"`
+}
+
+// NotebookResultTruncated returns true if the notebook result is incomplete
+func (o RunOutput) NotebookResultTruncated() bool {
+	return o.NotebookOutput != nil && o.NotebookOutput.Truncated
+}
+
+// RepairHistoryItem describes the original run or one of its repairs
+type RepairHistoryItem struct {
+	ID         int64    `json:"id,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	State      RunState `json:"state,omitempty"`
+	StartTime  int64    `json:"start_time,omitempty"`
+	EndTime    int64    `json:"end_time,omitempty"`
+	TaskRunIDs []int64  `json:"task_run_ids,omitempty"`
+}
+
+// RunTask is a simplified representation of a task within a multi-task job run
+type RunTask struct {
+	TaskKey         string           `json:"task_key,omitempty"`
+	RunID           int64            `json:"run_id,omitempty"`
+	State           RunState         `json:"state,omitempty"`
+	StartTime       int64            `json:"start_time,omitempty"`
+	EndTime         int64            `json:"end_time,omitempty"`
+	ClusterInstance *ClusterInstance `json:"cluster_instance,omitempty"`
+}
+
+// RunReport is a machine-readable summary of a run and its tasks, like for gating CI pipelines
+type RunReport struct {
+	JobID          int64        `json:"job_id"`
+	RunID          int64        `json:"run_id"`
+	State          RunState     `json:"state"`
+	DurationMillis int64        `json:"duration_ms"`
+	Error          string       `json:"error,omitempty"`
+	ErrorTrace     string       `json:"error_trace,omitempty"`
+	Tasks          []TaskReport `json:"tasks,omitempty"`
+}
+
+// TaskReport is a summary of a single task of the run in RunReport
+type TaskReport struct {
+	TaskKey        string   `json:"task_key"`
+	RunID          int64    `json:"run_id"`
+	State          RunState `json:"state"`
+	DurationMillis int64    `json:"duration_ms"`
+	Error          string   `json:"error,omitempty"`
+	ErrorTrace     string   `json:"error_trace,omitempty"`
+}
+
+// ClusterInstance identifies the cluster and Spark context used by a run
+type ClusterInstance struct {
+	ClusterID      string `json:"cluster_id,omitempty"`
+	SparkContextID string `json:"spark_context_id,omitempty"`
 }
 
 // JobRunsListRequest used to do what it sounds like
@@ -426,6 +535,8 @@ type JobRunsListRequest struct {
 	CompletedOnly bool  `url:"completed_only,omitempty"`
 	Offset        int32 `url:"offset,omitempty"`
 	Limit         int32 `url:"limit,omitempty"`
+	StartTimeFrom int64 `url:"start_time_from,omitempty"`
+	StartTimeTo   int64 `url:"start_time_to,omitempty"`
 }
 
 // JobRunsList returns a page of job runs
@@ -436,8 +547,9 @@ type JobRunsList struct {
 
 // UpdateJobRequest used to do what it sounds like
 type UpdateJobRequest struct {
-	JobID       int64        `json:"job_id,omitempty" url:"job_id,omitempty"`
-	NewSettings *JobSettings `json:"new_settings,omitempty" url:"new_settings,omitempty"`
+	JobID          int64        `json:"job_id,omitempty" url:"job_id,omitempty"`
+	NewSettings    *JobSettings `json:"new_settings,omitempty" url:"new_settings,omitempty"`
+	FieldsToRemove []string     `json:"fields_to_remove,omitempty" url:"fields_to_remove,omitempty"`
 }
 
 // NewJobsAPI creates JobsAPI instance from provider meta
@@ -675,6 +787,85 @@ func (a JobsAPI) ListByName(name string, expandTasks bool) ([]Job, error) {
 	return jobs, nil
 }
 
+// OwnedBy returns all jobs created by the given user
+func (a JobsAPI) OwnedBy(userName string) ([]Job, error) {
+	all, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+	jobs := []Job{}
+	for _, job := range all {
+		if job.CreatorUserName == userName {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// JobAccessGrant is a permission level granted on the job to a user, group or service principal
+type JobAccessGrant struct {
+	// PrincipalType is one of permissions.PrincipalUser, PrincipalGroup or PrincipalServicePrincipal
+	PrincipalType   string
+	Principal       string
+	PermissionLevel string
+	// Inherited is true for grants coming from the workspace-level job permissions
+	Inherited bool
+}
+
+// JobAccessSummary describes who can access the job and as whom it runs
+type JobAccessSummary struct {
+	JobID int64
+	// RunAs is the user name or service principal application ID that the job runs as
+	RunAs string
+	// RunAsType is either permissions.PrincipalUser or permissions.PrincipalServicePrincipal
+	RunAsType string
+	Grants    []JobAccessGrant
+}
+
+// EffectiveAccess combines the permissions of the job with the identity it runs as
+func (a JobsAPI) EffectiveAccess(jobID int64) (JobAccessSummary, error) {
+	job, err := a.Read(strconv.FormatInt(jobID, 10))
+	if err != nil {
+		return JobAccessSummary{}, err
+	}
+	acl, err := permissions.NewPermissionsAPI(a.context, a.client).Read(fmt.Sprintf("/jobs/%d", jobID))
+	if err != nil {
+		return JobAccessSummary{}, err
+	}
+	summary := JobAccessSummary{
+		JobID:  jobID,
+		Grants: []JobAccessGrant{},
+	}
+	if job.Settings != nil && job.Settings.RunAs != nil {
+		summary.RunAs = job.Settings.RunAs.UserName
+		summary.RunAsType = permissions.PrincipalUser
+		if job.Settings.RunAs.ServicePrincipalName != "" {
+			summary.RunAs = job.Settings.RunAs.ServicePrincipalName
+			summary.RunAsType = permissions.PrincipalServicePrincipal
+		}
+	}
+	for _, ac := range acl.AccessControlList {
+		grant := JobAccessGrant{
+			PrincipalType: permissions.PrincipalUser,
+			Principal:     ac.UserName,
+		}
+		if ac.GroupName != "" {
+			grant.PrincipalType = permissions.PrincipalGroup
+			grant.Principal = ac.GroupName
+		}
+		if ac.ServicePrincipalName != "" {
+			grant.PrincipalType = permissions.PrincipalServicePrincipal
+			grant.Principal = ac.ServicePrincipalName
+		}
+		for _, permission := range ac.AllPermissions {
+			grant.PermissionLevel = permission.PermissionLevel
+			grant.Inherited = permission.Inherited
+			summary.Grants = append(summary.Grants, grant)
+		}
+	}
+	return summary, nil
+}
+
 // List all jobs
 func (a JobsAPI) List() (l []Job, err error) {
 	l, err = a.ListByName("", false)
@@ -687,6 +878,70 @@ func (a JobsAPI) RunsList(r JobRunsListRequest) (jrl JobRunsList, err error) {
 	return
 }
 
+// RunsListInRange returns all runs of the job that started within the given time range, inclusive
+func (a JobsAPI) RunsListInRange(jobID int64, from, to time.Time) ([]JobRun, error) {
+	r := JobRunsListRequest{
+		JobID:         jobID,
+		Limit:         25,
+		StartTimeFrom: from.UnixMilli(),
+		StartTimeTo:   to.UnixMilli(),
+	}
+	runs := []JobRun{}
+	for {
+		page, err := a.RunsList(r)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range page.Runs {
+			// filter again, as not all API versions respect the time range
+			if run.StartTime >= r.StartTimeFrom && run.StartTime <= r.StartTimeTo {
+				runs = append(runs, run)
+			}
+		}
+		if !page.HasMore {
+			return runs, nil
+		}
+		r.Offset += int32(len(page.Runs))
+	}
+}
+
+// ActiveRuns returns pending and running runs of all jobs in the workspace
+func (a JobsAPI) ActiveRuns() ([]JobRun, error) {
+	return a.runsListAll(JobRunsListRequest{ActiveOnly: true})
+}
+
+// RunsByState returns completed runs of all jobs in the workspace with the given result state, like FAILED
+func (a JobsAPI) RunsByState(state string) ([]JobRun, error) {
+	completed, err := a.runsListAll(JobRunsListRequest{CompletedOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	runs := []JobRun{}
+	for _, run := range completed {
+		if run.State.ResultState == state {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+// runsListAll pages through all runs matching the request
+func (a JobsAPI) runsListAll(r JobRunsListRequest) ([]JobRun, error) {
+	r.Limit = 25
+	runs := []JobRun{}
+	for {
+		page, err := a.RunsList(r)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, page.Runs...)
+		if !page.HasMore {
+			return runs, nil
+		}
+		r.Offset += int32(len(page.Runs))
+	}
+}
+
 // RunsCancel cancels job run and waits till it's finished
 func (a JobsAPI) RunsCancel(runID int64, timeout time.Duration) error {
 	var response any
@@ -724,9 +979,17 @@ func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.
 
 // RunNow triggers the job and returns a run ID
 func (a JobsAPI) RunNow(jobID int64) (int64, error) {
+	return a.RunNowWithIdempotency(jobID, "")
+}
+
+// RunNowWithIdempotency triggers the job with the given idempotency token and returns a run ID. The token is
+// sent in the request body, so retries of the request and repeated calls with the same token don't launch
+// more than one run.
+func (a JobsAPI) RunNowWithIdempotency(jobID int64, idempotencyToken string) (int64, error) {
 	var jr JobRun
 	err := a.client.Post(a.context, "/jobs/run-now", RunParameters{
-		JobID: jobID,
+		JobID:            jobID,
+		IdempotencyToken: idempotencyToken,
 	}, &jr)
 	return jr.RunID, err
 }
@@ -740,6 +1003,95 @@ func (a JobsAPI) RunsGet(runID int64) (JobRun, error) {
 	return jr, err
 }
 
+// RunsGetOutput retrieves the output and metadata of a single task run
+func (a JobsAPI) RunsGetOutput(runID int64) (RunOutput, error) {
+	var output RunOutput
+	err := a.client.Get(a.context, "/jobs/runs/get-output", map[string]any{
+		"run_id": runID,
+	}, &output)
+	return output, err
+}
+
+// RunReport assembles states, durations and errors of the run and its tasks into a single report.
+// Run outputs are fetched only for the runs that didn't succeed, as only those carry error traces.
+// Single-task runs without task details report the error on the run itself.
+func (a JobsAPI) RunReport(runID int64) (RunReport, error) {
+	api := JobsAPI{a.client, context.WithValue(a.context, common.Api, common.API_2_1)}
+	run, err := api.RunsGet(runID)
+	if err != nil {
+		return RunReport{}, err
+	}
+	report := RunReport{
+		JobID:          run.JobID,
+		RunID:          run.RunID,
+		State:          run.State,
+		DurationMillis: durationMillis(run.StartTime, run.EndTime),
+	}
+	if len(run.Tasks) == 0 {
+		report.Error, report.ErrorTrace, err = api.runError(run.RunID, run.State)
+		return report, err
+	}
+	for _, task := range run.Tasks {
+		taskReport := TaskReport{
+			TaskKey:        task.TaskKey,
+			RunID:          task.RunID,
+			State:          task.State,
+			DurationMillis: durationMillis(task.StartTime, task.EndTime),
+		}
+		taskReport.Error, taskReport.ErrorTrace, err = api.runError(task.RunID, task.State)
+		if err != nil {
+			return report, fmt.Errorf("cannot get output of task %s: %w", task.TaskKey, err)
+		}
+		report.Tasks = append(report.Tasks, taskReport)
+	}
+	return report, nil
+}
+
+// runError returns the error and its trace from the output of a finished run that didn't succeed
+func (a JobsAPI) runError(runID int64, state RunState) (string, string, error) {
+	if state.ResultState == "" || state.ResultState == "SUCCESS" {
+		return "", "", nil
+	}
+	output, err := a.RunsGetOutput(runID)
+	return output.Error, output.ErrorTrace, err
+}
+
+func durationMillis(start, end int64) int64 {
+	if start == 0 || end < start {
+		return 0
+	}
+	return end - start
+}
+
+// RunsGetWithRepairHistory returns the run together with the history of its repairs
+func (a JobsAPI) RunsGetWithRepairHistory(runID int64) (JobRun, error) {
+	var jr JobRun
+	ctx := context.WithValue(a.context, common.Api, common.API_2_1)
+	err := a.client.Get(ctx, "/jobs/runs/get", map[string]any{
+		"run_id":          runID,
+		"include_history": true,
+	}, &jr)
+	return jr, err
+}
+
+// RunTaskClusters returns the mapping of task keys to IDs of clusters used by tasks of a run.
+// Tasks that didn't get a cluster yet are omitted.
+func (a JobsAPI) RunTaskClusters(runID int64) (map[string]string, error) {
+	api := JobsAPI{a.client, context.WithValue(a.context, common.Api, common.API_2_1)}
+	run, err := api.RunsGet(runID)
+	if err != nil {
+		return nil, err
+	}
+	clusters := map[string]string{}
+	for _, task := range run.Tasks {
+		if task.ClusterInstance == nil || task.ClusterInstance.ClusterID == "" {
+			continue
+		}
+		clusters[task.TaskKey] = task.ClusterInstance.ClusterID
+	}
+	return clusters, nil
+}
+
 func (a JobsAPI) Start(jobID int64, timeout time.Duration) error {
 	runID, err := a.RunNow(jobID)
 	if err != nil {
@@ -786,16 +1138,156 @@ func (a JobsAPI) Create(jobSettings JobSettings) (Job, error) {
 	return job, err
 }
 
+// stripReadOnlyFields removes fields that are returned by the API, but cannot be sent on create
+func (js *JobSettings) stripReadOnlyFields() {
+	js.Format = ""
+	if js.NewCluster != nil {
+		js.NewCluster.ClusterID = ""
+	}
+	for i := range js.Tasks {
+		if js.Tasks[i].NewCluster != nil {
+			js.Tasks[i].NewCluster.ClusterID = ""
+		}
+	}
+	for i := range js.JobClusters {
+		if js.JobClusters[i].NewCluster != nil {
+			js.JobClusters[i].NewCluster.ClusterID = ""
+		}
+	}
+}
+
+// Clone creates a copy of an existing job with the new name, applying non-empty fields
+// from overrides on top of the settings of the source job. Returns the ID of the new job.
+func (a JobsAPI) Clone(jobID int64, newName string, overrides JobSettings) (int64, error) {
+	api := JobsAPI{a.client, context.WithValue(a.context, common.Api, common.API_2_1)}
+	source, err := api.Read(fmt.Sprintf("%d", jobID))
+	if err != nil {
+		return 0, fmt.Errorf("cannot read job %d: %w", jobID, err)
+	}
+	if source.Settings == nil {
+		return 0, fmt.Errorf("job %d has no settings", jobID)
+	}
+	settings := *source.Settings
+	settings.applyOverrides(overrides)
+	settings.stripReadOnlyFields()
+	settings.Name = newName
+	job, err := api.Create(settings)
+	if err != nil {
+		return 0, fmt.Errorf("cannot create clone of job %d: %w", jobID, err)
+	}
+	return job.JobID, nil
+}
+
+// applyOverrides replaces fields of the settings with the non-zero fields of overrides. Fields are replaced
+// as a whole, so that no tasks, tags or other nested values of the original settings remain next to overrides.
+func (js *JobSettings) applyOverrides(overrides JobSettings) {
+	target := reflect.ValueOf(js).Elem()
+	source := reflect.ValueOf(overrides)
+	for i := 0; i < source.NumField(); i++ {
+		if !source.Field(i).IsZero() {
+			target.Field(i).Set(source.Field(i))
+		}
+	}
+}
+
+// ExportDefinition returns settings of the job as indented JSON without read-only fields,
+// so that it could be stored in version control and recreated with ImportDefinition
+func (a JobsAPI) ExportDefinition(jobID int64) ([]byte, error) {
+	api := JobsAPI{a.client, context.WithValue(a.context, common.Api, common.API_2_1)}
+	job, err := api.Read(fmt.Sprintf("%d", jobID))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read job %d: %w", jobID, err)
+	}
+	if job.Settings == nil {
+		return nil, fmt.Errorf("job %d has no settings", jobID)
+	}
+	job.Settings.stripReadOnlyFields()
+	return json.MarshalIndent(job.Settings, "", "  ")
+}
+
+// ImportDefinition creates a new job from the JSON produced by ExportDefinition and returns its ID
+func (a JobsAPI) ImportDefinition(jsonBytes []byte) (int64, error) {
+	var settings JobSettings
+	err := json.Unmarshal(jsonBytes, &settings)
+	if err != nil {
+		return 0, fmt.Errorf("invalid job definition: %w", err)
+	}
+	api := JobsAPI{a.client, context.WithValue(a.context, common.Api, common.API_2_1)}
+	job, err := api.Create(settings)
+	if err != nil {
+		return 0, err
+	}
+	return job.JobID, nil
+}
+
 // Update updates a job given the id and a new set of job settings
 func (a JobsAPI) Update(id string, jobSettings JobSettings) error {
 	jobID, err := parseJobId(id)
 	if err != nil {
 		return err
 	}
+	return a.Reset(jobID, jobSettings)
+}
+
+// Reset overwrites all settings of the job with the given ones, so that fields missing from settings
+// are removed from the job. Use PartialUpdate to change only some of the fields.
+func (a JobsAPI) Reset(jobID int64, settings JobSettings) error {
 	return wrapMissingJobError(a.client.Post(a.context, "/jobs/reset", UpdateJobRequest{
 		JobID:       jobID,
-		NewSettings: &jobSettings,
-	}, nil), id)
+		NewSettings: &settings,
+	}, nil), strconv.FormatInt(jobID, 10))
+}
+
+// PartialUpdate changes only the top-level fields set in newSettings and removes fieldsToRemove,
+// leaving all other job settings intact, unlike Reset that replaces all of them
+func (a JobsAPI) PartialUpdate(jobID int64, newSettings JobSettings, fieldsToRemove []string) error {
+	return wrapMissingJobError(a.client.Post(a.context, "/jobs/update", UpdateJobRequest{
+		JobID:          jobID,
+		NewSettings:    &newSettings,
+		FieldsToRemove: fieldsToRemove,
+	}, nil), strconv.FormatInt(jobID, 10))
+}
+
+// SchedulePauseStatus returns PAUSED or UNPAUSED for the job schedule
+func (a JobsAPI) SchedulePauseStatus(jobID int64) (string, error) {
+	schedule, err := a.readSchedule(jobID)
+	if err != nil {
+		return "", err
+	}
+	if schedule.PauseStatus == "" {
+		return "UNPAUSED", nil
+	}
+	return schedule.PauseStatus, nil
+}
+
+// PauseSchedule pauses the job schedule, keeping its cron expression and timezone
+func (a JobsAPI) PauseSchedule(jobID int64) error {
+	return a.setSchedulePauseStatus(jobID, "PAUSED")
+}
+
+// UnpauseSchedule resumes the job schedule, keeping its cron expression and timezone
+func (a JobsAPI) UnpauseSchedule(jobID int64) error {
+	return a.setSchedulePauseStatus(jobID, "UNPAUSED")
+}
+
+func (a JobsAPI) setSchedulePauseStatus(jobID int64, pauseStatus string) error {
+	schedule, err := a.readSchedule(jobID)
+	if err != nil {
+		return err
+	}
+	schedule.PauseStatus = pauseStatus
+	return a.PartialUpdate(jobID, JobSettings{Schedule: &schedule}, nil)
+}
+
+func (a JobsAPI) readSchedule(jobID int64) (CronSchedule, error) {
+	job, err := a.Read(strconv.FormatInt(jobID, 10))
+	if err != nil {
+		return CronSchedule{}, err
+	}
+	if job.Settings == nil || job.Settings.Schedule == nil {
+		return CronSchedule{}, fmt.Errorf("job %d has no schedule", jobID)
+	}
+	return *job.Settings.Schedule, nil
 }
 
 // Read returns the job object with all the attributes
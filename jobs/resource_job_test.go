@@ -12,6 +12,7 @@ import (
 	"github.com/databricks/databricks-sdk-go/service/jobs"
 	"github.com/databricks/terraform-provider-databricks/clusters"
 	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/permissions"
 	"github.com/databricks/terraform-provider-databricks/qa"
 
 	"github.com/stretchr/testify/assert"
@@ -3072,3 +3073,853 @@ func TestJobResource_SparkConfDiffSuppress(t *testing.T) {
 	assert.True(t, scs.DiffSuppressFunc("new_cluster.0.spark_conf.%", "1", "0", nil))
 	assert.False(t, scs.DiffSuppressFunc("new_cluster.0.spark_conf.%", "1", "1", nil))
 }
+
+func TestJobsAPIClone(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/get?job_id=123",
+			Response: Job{
+				JobID:           123,
+				CreatorUserName: "someone@example.com",
+				CreatedTime:     1700000000000,
+				Settings: &JobSettings{
+					Name:              "Source",
+					Format:            "MULTI_TASK",
+					MaxConcurrentRuns: 1,
+					TimeoutSeconds:    3600,
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey: "a",
+							NewCluster: &clusters.Cluster{
+								ClusterID:    "abc",
+								SparkVersion: "a",
+								NumWorkers:   2,
+							},
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Stuff",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/jobs/create",
+			ExpectedRequest: JobSettings{
+				Name:              "Clone",
+				MaxConcurrentRuns: 5,
+				TimeoutSeconds:    3600,
+				Tasks: []JobTaskSettings{
+					{
+						TaskKey: "a",
+						NewCluster: &clusters.Cluster{
+							SparkVersion: "a",
+							NumWorkers:   2,
+						},
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+					},
+				},
+			},
+			Response: Job{
+				JobID: 234,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewJobsAPI(ctx, client)
+		jobID, err := a.Clone(123, "Clone", JobSettings{
+			MaxConcurrentRuns: 5,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(234), jobID)
+	})
+}
+
+func TestJobsAPIClone_ReplacesTasksAndTags(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/get?job_id=123",
+			Response: Job{
+				JobID: 123,
+				Settings: &JobSettings{
+					Name:              "Source",
+					MaxConcurrentRuns: 1,
+					Tags: map[string]string{
+						"team": "data",
+						"env":  "prod",
+					},
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Stuff",
+							},
+						},
+						{
+							TaskKey:           "c",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Other",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/jobs/create",
+			ExpectedRequest: JobSettings{
+				Name:              "Clone",
+				MaxConcurrentRuns: 1,
+				Tags: map[string]string{
+					"env": "dev",
+				},
+				Tasks: []JobTaskSettings{
+					{
+						TaskKey:           "b",
+						ExistingClusterID: "bcd",
+						SparkPythonTask: &SparkPythonTask{
+							PythonFile: "/main.py",
+						},
+					},
+				},
+			},
+			Response: Job{
+				JobID: 234,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		jobID, err := NewJobsAPI(ctx, client).Clone(123, "Clone", JobSettings{
+			Tags: map[string]string{
+				"env": "dev",
+			},
+			Tasks: []JobTaskSettings{
+				{
+					TaskKey:           "b",
+					ExistingClusterID: "bcd",
+					SparkPythonTask: &SparkPythonTask{
+						PythonFile: "/main.py",
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(234), jobID)
+	})
+}
+
+func TestJobsAPIExportImportDefinition(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/get?job_id=123",
+			Response: Job{
+				JobID:           123,
+				CreatorUserName: "someone@example.com",
+				Settings: &JobSettings{
+					Name:              "Nightly",
+					Format:            "MULTI_TASK",
+					MaxConcurrentRuns: 1,
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey: "a",
+							NewCluster: &clusters.Cluster{
+								ClusterID:    "abc",
+								SparkVersion: "a",
+								NumWorkers:   2,
+							},
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Stuff",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/jobs/create",
+			ExpectedRequest: JobSettings{
+				Name:              "Nightly",
+				MaxConcurrentRuns: 1,
+				Tasks: []JobTaskSettings{
+					{
+						TaskKey: "a",
+						NewCluster: &clusters.Cluster{
+							SparkVersion: "a",
+							NumWorkers:   2,
+						},
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+					},
+				},
+			},
+			Response: Job{
+				JobID: 234,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewJobsAPI(ctx, client)
+		definition, err := a.ExportDefinition(123)
+		require.NoError(t, err)
+		assert.NotContains(t, string(definition), "MULTI_TASK")
+		assert.NotContains(t, string(definition), "cluster_id")
+
+		jobID, err := a.ImportDefinition(definition)
+		require.NoError(t, err)
+		assert.Equal(t, int64(234), jobID)
+
+		_, err = a.ImportDefinition([]byte("{"))
+		assert.ErrorContains(t, err, "invalid job definition")
+	})
+}
+
+func TestJobsAPIRunTaskClusters(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/runs/get?run_id=345",
+			Response: JobRun{
+				JobID: 123,
+				RunID: 345,
+				Tasks: []RunTask{
+					{
+						TaskKey: "ingest",
+						RunID:   346,
+						ClusterInstance: &ClusterInstance{
+							ClusterID:      "0101-abc",
+							SparkContextID: "1",
+						},
+					},
+					{
+						TaskKey: "transform",
+						RunID:   347,
+						ClusterInstance: &ClusterInstance{
+							ClusterID: "0101-bcd",
+						},
+					},
+					{
+						TaskKey: "pending",
+						RunID:   348,
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewJobsAPI(ctx, client)
+		taskClusters, err := a.RunTaskClusters(345)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"ingest":    "0101-abc",
+			"transform": "0101-bcd",
+		}, taskClusters)
+	})
+}
+
+func TestJobsAPIRunsGetOutput(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/get-output?run_id=346",
+			Response: `{
+				"metadata": {
+					"job_id": 123,
+					"run_id": 346,
+					"state": {"life_cycle_state": "TERMINATED", "result_state": "SUCCESS"}
+				},
+				"notebook_output": {
+					"result": "[{\"id\": 1}, {\"id\": 2}",
+					"truncated": true
+				},
+				"logs_truncated": false
+			}`,
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/get-output?run_id=347",
+			Response: RunOutput{
+				Metadata: &JobRun{
+					RunID: 347,
+				},
+				Error:         "ZeroDivisionError: division by zero",
+				ErrorTrace:    "Traceback (most recent call last): ...",
+				LogsTruncated: true,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewJobsAPI(ctx, client)
+		output, err := a.RunsGetOutput(346)
+		require.NoError(t, err)
+		assert.True(t, output.NotebookResultTruncated())
+		assert.False(t, output.LogsTruncated)
+		assert.Equal(t, int64(123), output.Metadata.JobID)
+		assert.Equal(t, "SUCCESS", output.Metadata.State.ResultState)
+
+		output, err = a.RunsGetOutput(347)
+		require.NoError(t, err)
+		assert.False(t, output.NotebookResultTruncated())
+		assert.True(t, output.LogsTruncated)
+		assert.Equal(t, "ZeroDivisionError: division by zero", output.Error)
+		assert.Equal(t, "Traceback (most recent call last): ...", output.ErrorTrace)
+	})
+}
+
+func TestJobsAPIRunNowWithIdempotency(t *testing.T) {
+	request := RunParameters{
+		JobID:            789,
+		IdempotencyToken: "trigger-1",
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/jobs/run-now",
+			ExpectedRequest: request,
+			Status:          503,
+			Response: common.APIErrorBody{
+				ErrorCode: "TEMPORARILY_UNAVAILABLE",
+				Message:   "try again later",
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/jobs/run-now",
+			ExpectedRequest: request,
+			Response: JobRun{
+				RunID: 890,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		runID, err := NewJobsAPI(ctx, client).RunNowWithIdempotency(789, "trigger-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(890), runID)
+	})
+}
+
+func TestJobsAPIRunReport(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/runs/get?run_id=100",
+			Response: JobRun{
+				JobID:     123,
+				RunID:     100,
+				StartTime: 1700000000000,
+				EndTime:   1700000090000,
+				State:     RunState{LifeCycleState: "TERMINATED", ResultState: "FAILED"},
+				Tasks: []RunTask{
+					{
+						TaskKey:   "ingest",
+						RunID:     101,
+						StartTime: 1700000000000,
+						EndTime:   1700000060000,
+						State:     RunState{LifeCycleState: "TERMINATED", ResultState: "SUCCESS"},
+					},
+					{
+						TaskKey:   "transform",
+						RunID:     102,
+						StartTime: 1700000060000,
+						EndTime:   1700000090000,
+						State:     RunState{LifeCycleState: "INTERNAL_ERROR", ResultState: "FAILED"},
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/runs/get-output?run_id=102",
+			Response: RunOutput{
+				Error:      "ZeroDivisionError: division by zero",
+				ErrorTrace: "Traceback (most recent call last): ...",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/runs/get?run_id=200",
+			Response: JobRun{
+				JobID: 234,
+				RunID: 200,
+				State: RunState{LifeCycleState: "TERMINATED", ResultState: "SUCCESS"},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewJobsAPI(ctx, client)
+		report, err := a.RunReport(100)
+		require.NoError(t, err)
+		assert.Equal(t, int64(123), report.JobID)
+		assert.Equal(t, "FAILED", report.State.ResultState)
+		assert.Equal(t, int64(90000), report.DurationMillis)
+		assert.Equal(t, []TaskReport{
+			{
+				TaskKey:        "ingest",
+				RunID:          101,
+				State:          RunState{LifeCycleState: "TERMINATED", ResultState: "SUCCESS"},
+				DurationMillis: 60000,
+			},
+			{
+				TaskKey:        "transform",
+				RunID:          102,
+				State:          RunState{LifeCycleState: "INTERNAL_ERROR", ResultState: "FAILED"},
+				DurationMillis: 30000,
+				Error:          "ZeroDivisionError: division by zero",
+				ErrorTrace:     "Traceback (most recent call last): ...",
+			},
+		}, report.Tasks)
+
+		report, err = a.RunReport(200)
+		require.NoError(t, err)
+		assert.Empty(t, report.Tasks)
+		assert.Empty(t, report.Error)
+		assert.Equal(t, int64(0), report.DurationMillis)
+	})
+}
+
+func TestJobsAPIOwnedBy(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/get?job_id=123",
+			Response: `{
+				"job_id": 123,
+				"creator_user_name": "alice@example.com",
+				"run_as_user_name": "etl@example.com",
+				"created_time": 1700000000000,
+				"settings": {"name": "nightly"}
+			}`,
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/list?expand_tasks=false&limit=25",
+			Response: JobListResponse{
+				Jobs: []Job{
+					{JobID: 123, CreatorUserName: "alice@example.com"},
+					{JobID: 234, CreatorUserName: "bob@example.com"},
+					{JobID: 345, CreatorUserName: "alice@example.com"},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewJobsAPI(ctx, client)
+		job, err := a.Read("123")
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", job.CreatorUserName)
+		assert.Equal(t, "etl@example.com", job.RunAsUserName)
+		assert.Equal(t, int64(1700000000000), job.CreatedTime)
+
+		jobs, err := a.OwnedBy("alice@example.com")
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		assert.Equal(t, int64(123), jobs[0].JobID)
+		assert.Equal(t, int64(345), jobs[1].JobID)
+	})
+}
+
+func TestJobsAPIRunsGetWithRepairHistory(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/runs/get?include_history=true&run_id=345",
+			Response: JobRun{
+				JobID: 123,
+				RunID: 345,
+				RepairHistory: []RepairHistoryItem{
+					{
+						Type:       "ORIGINAL",
+						StartTime:  1700000000000,
+						EndTime:    1700000100000,
+						State:      RunState{LifeCycleState: "TERMINATED", ResultState: "FAILED"},
+						TaskRunIDs: []int64{346, 347},
+					},
+					{
+						ID:         1,
+						Type:       "REPAIR",
+						StartTime:  1700000200000,
+						EndTime:    1700000300000,
+						State:      RunState{LifeCycleState: "TERMINATED", ResultState: "FAILED"},
+						TaskRunIDs: []int64{348},
+					},
+					{
+						ID:         2,
+						Type:       "REPAIR",
+						StartTime:  1700000400000,
+						EndTime:    1700000500000,
+						State:      RunState{LifeCycleState: "TERMINATED", ResultState: "SUCCESS"},
+						TaskRunIDs: []int64{349},
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		run, err := NewJobsAPI(ctx, client).RunsGetWithRepairHistory(345)
+		require.NoError(t, err)
+		require.Len(t, run.RepairHistory, 3)
+		assert.Equal(t, "ORIGINAL", run.RepairHistory[0].Type)
+		assert.Equal(t, []int64{346, 347}, run.RepairHistory[0].TaskRunIDs)
+		assert.Equal(t, "REPAIR", run.RepairHistory[2].Type)
+		assert.Equal(t, int64(2), run.RepairHistory[2].ID)
+		assert.Equal(t, "SUCCESS", run.RepairHistory[2].State.ResultState)
+		assert.Equal(t, int64(1700000400000), run.RepairHistory[2].StartTime)
+	})
+}
+
+func TestJobsAPIRunsListInRange(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/list?job_id=123&limit=25&start_time_from=1700000000000&start_time_to=1700086400000",
+			Response: JobRunsList{
+				Runs: []JobRun{
+					{RunID: 1, StartTime: 1699999999999},
+					{RunID: 2, StartTime: 1700000000000},
+				},
+				HasMore: true,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/list?job_id=123&limit=25&offset=2&start_time_from=1700000000000&start_time_to=1700086400000",
+			Response: JobRunsList{
+				Runs: []JobRun{
+					{RunID: 3, StartTime: 1700050000000},
+					{RunID: 4, StartTime: 1700086400001},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewJobsAPI(ctx, client)
+		runs, err := a.RunsListInRange(123, time.UnixMilli(1700000000000), time.UnixMilli(1700086400000))
+		require.NoError(t, err)
+		var ids []int64
+		for _, run := range runs {
+			ids = append(ids, run.RunID)
+		}
+		assert.Equal(t, []int64{2, 3}, ids)
+	})
+}
+
+func TestJobsAPIActiveRuns(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/list?active_only=true&limit=25",
+			Response: JobRunsList{
+				Runs: []JobRun{
+					{JobID: 123, RunID: 1, State: RunState{LifeCycleState: "RUNNING"}},
+					{JobID: 234, RunID: 2, State: RunState{LifeCycleState: "PENDING"}},
+				},
+				HasMore: true,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/list?active_only=true&limit=25&offset=2",
+			Response: JobRunsList{
+				Runs: []JobRun{
+					{JobID: 345, RunID: 3, State: RunState{LifeCycleState: "RUNNING"}},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		runs, err := NewJobsAPI(ctx, client).ActiveRuns()
+		require.NoError(t, err)
+		var jobIDs []int64
+		for _, run := range runs {
+			jobIDs = append(jobIDs, run.JobID)
+		}
+		assert.Equal(t, []int64{123, 234, 345}, jobIDs)
+	})
+}
+
+func TestJobsAPIRunsByState(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/list?completed_only=true&limit=25",
+			Response: JobRunsList{
+				Runs: []JobRun{
+					{JobID: 123, RunID: 1, State: RunState{LifeCycleState: "TERMINATED", ResultState: "SUCCESS"}},
+					{JobID: 234, RunID: 2, State: RunState{LifeCycleState: "TERMINATED", ResultState: "FAILED"}},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		runs, err := NewJobsAPI(ctx, client).RunsByState("FAILED")
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, int64(2), runs[0].RunID)
+	})
+}
+
+func TestJobsAPIPartialUpdate(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/jobs/update",
+			ExpectedRequest: UpdateJobRequest{
+				JobID: 123,
+				NewSettings: &JobSettings{
+					Schedule: &CronSchedule{
+						QuartzCronExpression: "0 0 2 * * ?",
+						TimezoneID:           "UTC",
+					},
+				},
+				FieldsToRemove: []string{"email_notifications"},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewJobsAPI(ctx, client).PartialUpdate(123, JobSettings{
+			Schedule: &CronSchedule{
+				QuartzCronExpression: "0 0 2 * * ?",
+				TimezoneID:           "UTC",
+			},
+		}, []string{"email_notifications"})
+		require.NoError(t, err)
+	})
+}
+
+func TestJobsAPIPauseSchedule(t *testing.T) {
+	scheduledJob := func(pauseStatus string) Job {
+		return Job{
+			JobID: 123,
+			Settings: &JobSettings{
+				Name: "Nightly",
+				Schedule: &CronSchedule{
+					QuartzCronExpression: "0 0 2 * * ?",
+					TimezoneID:           "Europe/Amsterdam",
+					PauseStatus:          pauseStatus,
+				},
+			},
+		}
+	}
+	updateSchedule := func(pauseStatus string) UpdateJobRequest {
+		return UpdateJobRequest{
+			JobID: 123,
+			NewSettings: &JobSettings{
+				Schedule: &CronSchedule{
+					QuartzCronExpression: "0 0 2 * * ?",
+					TimezoneID:           "Europe/Amsterdam",
+					PauseStatus:          pauseStatus,
+				},
+			},
+		}
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/get?job_id=123",
+			Response: scheduledJob("UNPAUSED"),
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/jobs/update",
+			ExpectedRequest: updateSchedule("PAUSED"),
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/get?job_id=123",
+			Response: scheduledJob("PAUSED"),
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/get?job_id=123",
+			Response: scheduledJob("PAUSED"),
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/jobs/update",
+			ExpectedRequest: updateSchedule("UNPAUSED"),
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/get?job_id=123",
+			Response: scheduledJob(""),
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/get?job_id=456",
+			Response: Job{
+				JobID:    456,
+				Settings: &JobSettings{Name: "Manual"},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		jobsAPI := NewJobsAPI(ctx, client)
+		require.NoError(t, jobsAPI.PauseSchedule(123))
+		status, err := jobsAPI.SchedulePauseStatus(123)
+		require.NoError(t, err)
+		assert.Equal(t, "PAUSED", status)
+
+		require.NoError(t, jobsAPI.UnpauseSchedule(123))
+		status, err = jobsAPI.SchedulePauseStatus(123)
+		require.NoError(t, err)
+		assert.Equal(t, "UNPAUSED", status)
+
+		assert.EqualError(t, jobsAPI.PauseSchedule(456), "job 456 has no schedule")
+	})
+}
+
+func TestJobsAPIEffectiveAccess(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/get?job_id=123",
+			Response: Job{
+				JobID:         123,
+				RunAsUserName: "3f9ab08c-7b5c-4a3e-8d4f-0b1a2c3d4e5f",
+				Settings: &JobSettings{
+					Name: "Nightly",
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/permissions/jobs/123",
+			Response: permissions.ObjectACL{
+				ObjectID:   "/jobs/123",
+				ObjectType: "job",
+				AccessControlList: []permissions.AccessControl{
+					{
+						UserName: "owner@example.com",
+						AllPermissions: []permissions.Permission{
+							{PermissionLevel: "IS_OWNER"},
+						},
+					},
+					{
+						GroupName: "data-engineers",
+						AllPermissions: []permissions.Permission{
+							{PermissionLevel: "CAN_MANAGE"},
+						},
+					},
+					{
+						GroupName: "admins",
+						AllPermissions: []permissions.Permission{
+							{
+								PermissionLevel:     "CAN_MANAGE",
+								Inherited:           true,
+								InheritedFromObject: []string{"/jobs/"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		summary, err := NewJobsAPI(ctx, client).EffectiveAccess(123)
+		require.NoError(t, err)
+		assert.Equal(t, JobAccessSummary{
+			JobID:     123,
+			RunAs:     "3f9ab08c-7b5c-4a3e-8d4f-0b1a2c3d4e5f",
+			RunAsType: permissions.PrincipalServicePrincipal,
+			Grants: []JobAccessGrant{
+				{
+					PrincipalType:   permissions.PrincipalUser,
+					Principal:       "owner@example.com",
+					PermissionLevel: "IS_OWNER",
+				},
+				{
+					PrincipalType:   permissions.PrincipalGroup,
+					Principal:       "data-engineers",
+					PermissionLevel: "CAN_MANAGE",
+				},
+				{
+					PrincipalType:   permissions.PrincipalGroup,
+					Principal:       "admins",
+					PermissionLevel: "CAN_MANAGE",
+					Inherited:       true,
+				},
+			},
+		}, summary)
+	})
+}
+
+func TestJobRunQueueDuration(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/get?run_id=1",
+			Response: map[string]any{
+				"run_id":             1,
+				"start_time":         1700000000000,
+				"end_time":           1700000100000,
+				"setup_duration":     20000,
+				"execution_duration": 60000,
+				"cleanup_duration":   5000,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/get?run_id=2",
+			Response: map[string]any{
+				"run_id":         2,
+				"queue_duration": 42000,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewJobsAPI(ctx, client)
+		run, err := a.RunsGet(1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(20000), run.SetupDuration)
+		assert.Equal(t, int64(60000), run.ExecutionDuration)
+		assert.Equal(t, int64(5000), run.CleanupDuration)
+		assert.Equal(t, 15*time.Second, QueueDuration(run))
+
+		run, err = a.RunsGet(2)
+		require.NoError(t, err)
+		assert.Equal(t, 42*time.Second, QueueDuration(run))
+	})
+}
+
+func TestJobRunQueueDuration_MultiTask(t *testing.T) {
+	run := JobRun{
+		StartTime: 1700000000000,
+		EndTime:   1700000100000,
+		Tasks: []RunTask{
+			{
+				TaskKey: "a",
+			},
+			{
+				TaskKey: "b",
+			},
+		},
+	}
+	assert.Equal(t, time.Duration(0), QueueDuration(run))
+
+	run.RunDuration = 90000
+	assert.Equal(t, 10*time.Second, QueueDuration(run))
+}
+
+func TestJobsAPIReset(t *testing.T) {
+	settings := JobSettings{
+		Name:              "Nightly",
+		MaxConcurrentRuns: 1,
+		TimeoutSeconds:    3600,
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:           "a",
+				ExistingClusterID: "abc",
+				NotebookTask: &NotebookTask{
+					NotebookPath: "/Stuff",
+				},
+			},
+		},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/jobs/reset",
+			ExpectedRequest: UpdateJobRequest{
+				JobID:       123,
+				NewSettings: &settings,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewJobsAPI(ctx, client).Reset(123, settings)
+		require.NoError(t, err)
+	})
+}
@@ -1,6 +1,17 @@
 package mws
 
-import "github.com/databricks/databricks-sdk-go/marshal"
+import (
+	"github.com/databricks/databricks-sdk-go/marshal"
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+// accountIDOrDefault returns the given account ID, falling back to the account ID of the client when it's empty
+func accountIDOrDefault(client *common.DatabricksClient, accountID string) string {
+	if accountID != "" {
+		return accountID
+	}
+	return client.AccountID()
+}
 
 // StsRole is the object that contains cross account role arn and external app id
 type StsRole struct {
@@ -23,6 +23,7 @@ type CredentialsAPI struct {
 
 // List lists all the available credentials object in the mws account
 func (a CredentialsAPI) List(mwsAcctID string) ([]Credentials, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	var mwsCredsList []Credentials
 	credentialsAPIPath := fmt.Sprintf("/accounts/%s/credentials", mwsAcctID)
 	err := a.client.Get(a.context, credentialsAPIPath, nil, &mwsCredsList)
@@ -54,6 +54,7 @@ func (a CustomerManagedKeysAPI) Create(cmk CustomerManagedKey) (k CustomerManage
 // Read returns the customer managed key object along with metadata
 func (a CustomerManagedKeysAPI) Read(
 	accountID, customerManagedKeyID string) (k CustomerManagedKey, err error) {
+	accountID = accountIDOrDefault(a.client, accountID)
 	err = a.client.Get(a.context, fmt.Sprintf("/accounts/%s/customer-managed-keys/%s",
 		accountID, customerManagedKeyID), nil, &k)
 	return
@@ -61,12 +62,14 @@ func (a CustomerManagedKeysAPI) Read(
 
 // Delete deletes the customer managed key object given a network id
 func (a CustomerManagedKeysAPI) Delete(accountID, customerManagedKeyID string) error {
+	accountID = accountIDOrDefault(a.client, accountID)
 	return a.client.Delete(a.context, fmt.Sprintf("/accounts/%s/customer-managed-keys/%s",
 		accountID, customerManagedKeyID), nil)
 }
 
 // List lists all the available customer managed key objects in the mws account
 func (a CustomerManagedKeysAPI) List(accountID string) (kl []CustomerManagedKey, err error) {
+	accountID = accountIDOrDefault(a.client, accountID)
 	err = a.client.Get(a.context, fmt.Sprintf("/accounts/%s/customer-managed-keys", accountID), nil, &kl)
 	return
 }
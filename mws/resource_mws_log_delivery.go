@@ -43,6 +43,7 @@ func NewLogDeliveryAPI(ctx context.Context, m any) LogDeliveryAPI {
 
 // Read reads log delivery configuration
 func (a LogDeliveryAPI) Read(accountID, configID string) (LogDeliveryConfiguration, error) {
+	accountID = accountIDOrDefault(a.client, accountID)
 	var ld LogDelivery
 	err := a.client.Get(a.context, fmt.Sprintf("/accounts/%s/log-delivery/%s", accountID, configID), nil, &ld)
 	return ld.LogDeliveryConfiguration, err
@@ -60,6 +61,7 @@ func (a LogDeliveryAPI) Create(ldc LogDeliveryConfiguration) (string, error) {
 
 // patch log delivery configuration - i.e. can only enable or disable it
 func (a LogDeliveryAPI) Patch(accountID, configID string, status string) error {
+	accountID = accountIDOrDefault(a.client, accountID)
 	return a.client.Patch(a.context, fmt.Sprintf("/accounts/%s/log-delivery/%s", accountID, configID), map[string]string{
 		"status": status,
 	})
@@ -33,6 +33,7 @@ func (a NetworksAPI) Create(network *Network) error {
 
 // Read returns the network object along with metadata and any additional errors when attaching to workspace
 func (a NetworksAPI) Read(mwsAcctID, networksID string) (Network, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	var mwsNetwork Network
 	networksAPIPath := fmt.Sprintf("/accounts/%s/networks/%s", mwsAcctID, networksID)
 	err := a.client.Get(a.context, networksAPIPath, nil, &mwsNetwork)
@@ -41,6 +42,7 @@ func (a NetworksAPI) Read(mwsAcctID, networksID string) (Network, error) {
 
 // Delete deletes the network object given a network id
 func (a NetworksAPI) Delete(mwsAcctID, networksID string) error {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	networksAPIPath := fmt.Sprintf("/accounts/%s/networks/%s", mwsAcctID, networksID)
 	if err := a.client.Delete(a.context, networksAPIPath, nil); err != nil {
 		return err
@@ -62,6 +64,7 @@ func (a NetworksAPI) Delete(mwsAcctID, networksID string) error {
 
 // List lists all the available network objects in the mws account
 func (a NetworksAPI) List(mwsAcctID string) ([]Network, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	var mwsNetworkList []Network
 	networksAPIPath := fmt.Sprintf("/accounts/%s/networks", mwsAcctID)
 	err := a.client.Get(a.context, networksAPIPath, nil, &mwsNetworkList)
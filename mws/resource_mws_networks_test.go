@@ -1,12 +1,14 @@
 package mws
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceNetworkCreate(t *testing.T) {
@@ -350,3 +352,43 @@ func TestResourceNetworkDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/nid", d.Id())
 }
+
+func TestNetworksAPIUsesClientAccountID(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/networks",
+			Response: []Network{
+				{
+					NetworkID:   "n1",
+					NetworkName: "default-account",
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/other/networks",
+			Response: []Network{
+				{
+					NetworkID:   "n2",
+					NetworkName: "overridden-account",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	client.Config.AccountID = "abc"
+	assert.Equal(t, "abc", client.AccountID())
+	a := NewNetworksAPI(context.Background(), client)
+
+	networks, err := a.List("")
+	require.NoError(t, err)
+	require.Len(t, networks, 1)
+	assert.Equal(t, "default-account", networks[0].NetworkName)
+
+	networks, err = a.List("other")
+	require.NoError(t, err)
+	require.Len(t, networks, 1)
+	assert.Equal(t, "overridden-account", networks[0].NetworkName)
+}
@@ -22,6 +22,7 @@ type StorageConfigurationsAPI struct {
 
 // Create creates a configuration for the root s3 bucket
 func (a StorageConfigurationsAPI) Create(mwsAcctID, storageConfigurationName string, bucketName string) (StorageConfiguration, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	var mwsStorageConfigurations StorageConfiguration
 	storageConfigurationAPIPath := fmt.Sprintf("/accounts/%s/storage-configurations", mwsAcctID)
 	err := a.client.Post(a.context, storageConfigurationAPIPath, StorageConfiguration{
@@ -35,6 +36,7 @@ func (a StorageConfigurationsAPI) Create(mwsAcctID, storageConfigurationName str
 
 // Read returns the configuration for the root s3 bucket and metadata for the storage configuration
 func (a StorageConfigurationsAPI) Read(mwsAcctID, storageConfigurationID string) (StorageConfiguration, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	var mwsStorageConfigurations StorageConfiguration
 	storageConfigurationAPIPath := fmt.Sprintf("/accounts/%s/storage-configurations/%s", mwsAcctID, storageConfigurationID)
 	err := a.client.Get(a.context, storageConfigurationAPIPath, nil, &mwsStorageConfigurations)
@@ -43,12 +45,14 @@ func (a StorageConfigurationsAPI) Read(mwsAcctID, storageConfigurationID string)
 
 // Delete deletes the configuration for the root s3 bucket
 func (a StorageConfigurationsAPI) Delete(mwsAcctID, storageConfigurationID string) error {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	storageConfigurationAPIPath := fmt.Sprintf("/accounts/%s/storage-configurations/%s", mwsAcctID, storageConfigurationID)
 	return a.client.Delete(a.context, storageConfigurationAPIPath, nil)
 }
 
 // List lists all the storage configurations for the root s3 buckets in the account ID provided to the client config
 func (a StorageConfigurationsAPI) List(mwsAcctID string) ([]StorageConfiguration, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	var mwsStorageConfigurationsList []StorageConfiguration
 	storageConfigurationAPIPath := fmt.Sprintf("/accounts/%s/storage-configurations", mwsAcctID)
 	err := a.client.Get(a.context, storageConfigurationAPIPath, nil, &mwsStorageConfigurationsList)
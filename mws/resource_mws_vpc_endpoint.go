@@ -55,6 +55,7 @@ func (a VPCEndpointAPI) Create(vpcEndpoint *VPCEndpoint) error {
 
 // Read returns the VPCEndpoint object along with metadata and any additional errors when attaching to workspace
 func (a VPCEndpointAPI) Read(mwsAcctID, vpcEndpointID string) (ve VPCEndpoint, err error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	vpcEndpointAPIPath := fmt.Sprintf("/accounts/%s/vpc-endpoints/%s", mwsAcctID, vpcEndpointID)
 	err = a.client.Get(a.context, vpcEndpointAPIPath, nil, &ve)
 	return
@@ -62,12 +63,14 @@ func (a VPCEndpointAPI) Read(mwsAcctID, vpcEndpointID string) (ve VPCEndpoint, e
 
 // Delete deletes the VPCEndpoint object given a VPCEndpoint id
 func (a VPCEndpointAPI) Delete(mwsAcctID, vpcEndpointID string) error {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	vpcEndpointAPIPath := fmt.Sprintf("/accounts/%s/vpc-endpoints/%s", mwsAcctID, vpcEndpointID)
 	return a.client.Delete(a.context, vpcEndpointAPIPath, nil)
 }
 
 // List lists all the available network objects in the mws account
 func (a VPCEndpointAPI) List(mwsAcctID string) ([]VPCEndpoint, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	var mwsVPCEndpointList []VPCEndpoint
 	vpcEndpointAPIPath := fmt.Sprintf("/accounts/%s/vpc-endpoints", mwsAcctID)
 	err := a.client.Get(a.context, vpcEndpointAPIPath, nil, &mwsVPCEndpointList)
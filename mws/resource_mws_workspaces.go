@@ -305,6 +305,7 @@ func (a WorkspacesAPI) UpdateRunning(ws Workspace, timeout time.Duration) error
 
 // Read will return the mws workspace metadata and status of the workspace deployment
 func (a WorkspacesAPI) Read(mwsAcctID, workspaceID string) (Workspace, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	var mwsWorkspace Workspace
 	workspacesAPIPath := fmt.Sprintf("/accounts/%s/workspaces/%s", mwsAcctID, workspaceID)
 	err := a.client.Get(a.context, workspacesAPIPath, nil, &mwsWorkspace)
@@ -319,6 +320,7 @@ func (a WorkspacesAPI) Read(mwsAcctID, workspaceID string) (Workspace, error) {
 // Delete will delete the configuration for the workspace given a workspace id
 // and wait till it's properly removed
 func (a WorkspacesAPI) Delete(mwsAcctID, workspaceID string) error {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	workspacesAPIPath := fmt.Sprintf("/accounts/%s/workspaces/%s", mwsAcctID, workspaceID)
 	err := a.client.Delete(a.context, workspacesAPIPath, nil)
 	if err != nil {
@@ -340,14 +342,47 @@ func (a WorkspacesAPI) Delete(mwsAcctID, workspaceID string) error {
 	})
 }
 
+// DeleteFailed deletes the workspace that is in FAILED state after unsuccessful provisioning and waits till
+// it's removed, so that it can be created again. Workspaces in other states are left untouched.
+func (a WorkspacesAPI) DeleteFailed(accountID string, workspaceID int64) error {
+	accountID = accountIDOrDefault(a.client, accountID)
+	id := fmt.Sprintf("%d", workspaceID)
+	workspace, err := a.Read(accountID, id)
+	if err != nil {
+		return err
+	}
+	if workspace.WorkspaceStatus != WorkspaceStatusFailed {
+		return fmt.Errorf("workspace %d is in %s state, only workspaces in %s state can be deleted",
+			workspaceID, workspace.WorkspaceStatus, WorkspaceStatusFailed)
+	}
+	log.Printf("[INFO] Deleting failed workspace %s: %s", workspace.WorkspaceName, workspace.WorkspaceStatusMessage)
+	return a.Delete(accountID, id)
+}
+
 // List will list all workspaces in a given mws account
 func (a WorkspacesAPI) List(mwsAcctID string) ([]Workspace, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
 	var mwsWorkspacesList []Workspace
 	workspacesAPIPath := fmt.Sprintf("/accounts/%s/workspaces", mwsAcctID)
 	err := a.client.Get(a.context, workspacesAPIPath, nil, &mwsWorkspacesList)
 	return mwsWorkspacesList, err
 }
 
+// GetByName returns the workspace with the given deployment name in a given mws account
+func (a WorkspacesAPI) GetByName(mwsAcctID, deploymentName string) (Workspace, error) {
+	mwsAcctID = accountIDOrDefault(a.client, mwsAcctID)
+	workspaces, err := a.List(mwsAcctID)
+	if err != nil {
+		return Workspace{}, err
+	}
+	for _, ws := range workspaces {
+		if ws.DeploymentName == deploymentName {
+			return ws, nil
+		}
+	}
+	return Workspace{}, apierr.NotFound(fmt.Sprintf("workspace with deployment name %s not found", deploymentName))
+}
+
 type Token struct {
 	LifetimeSeconds int32           `json:"lifetime_seconds,omitempty" tf:"default:2592000"`
 	Comment         string          `json:"comment,omitempty" tf:"default:Terraform PAT"`
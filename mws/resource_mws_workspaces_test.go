@@ -954,6 +954,51 @@ func TestResourceWorkspaceDelete_Error(t *testing.T) {
 	assert.Equal(t, "abc/1234", d.Id())
 }
 
+func TestWorkspacesAPIDeleteFailed(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces/1234",
+			Response: Workspace{
+				WorkspaceID:            1234,
+				WorkspaceName:          "labdata",
+				DeploymentName:         "900150983cd24fb0",
+				WorkspaceStatus:        WorkspaceStatusFailed,
+				WorkspaceStatusMessage: "Network validation failed",
+			},
+		},
+		{
+			Method:   "DELETE",
+			Resource: "/api/2.0/accounts/abc/workspaces/1234",
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces/1234",
+			Response: common.APIErrorBody{
+				ErrorCode: "NOT_FOUND",
+				Message:   "Cannot find anything",
+			},
+			Status: 404,
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces/2345",
+			Response: Workspace{
+				WorkspaceID:     2345,
+				DeploymentName:  "900150983cd24fb1",
+				WorkspaceStatus: WorkspaceStatusRunning,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewWorkspacesAPI(ctx, client)
+		err := a.DeleteFailed("abc", 1234)
+		assert.NoError(t, err)
+
+		err = a.DeleteFailed("abc", 2345)
+		assert.EqualError(t, err, "workspace 2345 is in RUNNING state, only workspaces in FAILED state can be deleted")
+	})
+}
+
 func TestWaitForRunning(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
@@ -1120,6 +1165,37 @@ func TestListWorkspaces(t *testing.T) {
 	assert.Len(t, l, 0)
 }
 
+func TestWorkspacesGetByName(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/accounts/abc/workspaces",
+			ReuseRequest: true,
+			Response: []Workspace{
+				{
+					WorkspaceID:    1,
+					WorkspaceName:  "dev",
+					DeploymentName: "acme-dev",
+				},
+				{
+					WorkspaceID:    2,
+					WorkspaceName:  "prod",
+					DeploymentName: "acme-prod",
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewWorkspacesAPI(ctx, client)
+		ws, err := a.GetByName("abc", "acme-prod")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), ws.WorkspaceID)
+
+		_, err = a.GetByName("abc", "acme-staging")
+		assert.True(t, apierr.IsMissing(err))
+		assert.EqualError(t, err, "workspace with deployment name acme-staging not found")
+	})
+}
+
 func TestWorkspace_WaitForResolve_Failure(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{},
 		func(ctx context.Context, client *common.DatabricksClient) {
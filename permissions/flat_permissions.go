@@ -0,0 +1,78 @@
+package permissions
+
+import (
+	"fmt"
+
+	"github.com/databricks/terraform-provider-databricks/workspace"
+)
+
+// FlatPermission is a single permission of a principal on a workspace object
+type FlatPermission struct {
+	ObjectPath      string `json:"object_path"`
+	ObjectType      string `json:"object_type"`
+	PrincipalName   string `json:"principal_name"`
+	PrincipalType   string `json:"principal_type"`
+	PermissionLevel string `json:"permission_level"`
+	Inherited       bool   `json:"inherited,omitempty"`
+}
+
+var workspaceObjectPermissionTypes = map[string]string{
+	workspace.Notebook:  "notebooks",
+	workspace.Directory: "directories",
+	workspace.File:      "files",
+	"REPO":              "repos",
+}
+
+// FlattenPermissions returns one row per object, principal and permission level for the given
+// workspace path and every object below it. Objects without permissions, like libraries, are skipped.
+func (a PermissionsAPI) FlattenPermissions(path string) ([]FlatPermission, error) {
+	notebooksAPI := workspace.NewNotebooksAPI(a.context, a.client)
+	root, err := notebooksAPI.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	objects := []workspace.ObjectStatus{root}
+	if root.ObjectType == workspace.Directory {
+		children, err := notebooksAPI.List(path, true, false)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, children...)
+	}
+	flat := []FlatPermission{}
+	for _, object := range objects {
+		resourceType, ok := workspaceObjectPermissionTypes[object.ObjectType]
+		if !ok {
+			continue
+		}
+		objectACL, err := a.Read(fmt.Sprintf("/%s/%d", resourceType, object.ObjectID))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read permissions for %s: %w", object.Path, err)
+		}
+		for _, ac := range objectACL.AccessControlList {
+			principalName, principalType := ac.principal()
+			for _, permission := range ac.AllPermissions {
+				flat = append(flat, FlatPermission{
+					ObjectPath:      object.Path,
+					ObjectType:      object.ObjectType,
+					PrincipalName:   principalName,
+					PrincipalType:   principalType,
+					PermissionLevel: permission.PermissionLevel,
+					Inherited:       permission.Inherited,
+				})
+			}
+		}
+	}
+	return flat, nil
+}
+
+func (ac AccessControl) principal() (name string, principalType string) {
+	switch {
+	case ac.UserName != "":
+		return ac.UserName, PrincipalUser
+	case ac.GroupName != "":
+		return ac.GroupName, PrincipalGroup
+	default:
+		return ac.ServicePrincipalName, PrincipalServicePrincipal
+	}
+}
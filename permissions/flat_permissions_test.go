@@ -0,0 +1,110 @@
+package permissions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/databricks/terraform-provider-databricks/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenPermissions(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fteam",
+			Response: workspace.ObjectStatus{
+				ObjectID:   1,
+				ObjectType: workspace.Directory,
+				Path:       "/Shared/team",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/list?path=%2FShared%2Fteam",
+			Response: workspace.ObjectList{
+				Objects: []workspace.ObjectStatus{
+					{
+						ObjectID:   2,
+						ObjectType: workspace.Notebook,
+						Path:       "/Shared/team/etl",
+					},
+					{
+						ObjectID:   3,
+						ObjectType: "LIBRARY",
+						Path:       "/Shared/team/lib",
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/permissions/directories/1",
+			Response: ObjectACL{
+				ObjectID:   "/directories/1",
+				ObjectType: "directory",
+				AccessControlList: []AccessControl{
+					{
+						GroupName: "admins",
+						AllPermissions: []Permission{
+							{PermissionLevel: "CAN_MANAGE", Inherited: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/permissions/notebooks/2",
+			Response: ObjectACL{
+				ObjectID:   "/notebooks/2",
+				ObjectType: "notebook",
+				AccessControlList: []AccessControl{
+					{
+						GroupName: "admins",
+						AllPermissions: []Permission{
+							{PermissionLevel: "CAN_MANAGE", Inherited: true},
+						},
+					},
+					{
+						ServicePrincipalName: "00000000-0000-0000-0000-000000000001",
+						AllPermissions: []Permission{
+							{PermissionLevel: "CAN_RUN"},
+						},
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		flat, err := NewPermissionsAPI(ctx, client).FlattenPermissions("/Shared/team")
+		require.NoError(t, err)
+		assert.Equal(t, []FlatPermission{
+			{
+				ObjectPath:      "/Shared/team",
+				ObjectType:      workspace.Directory,
+				PrincipalName:   "admins",
+				PrincipalType:   "group",
+				PermissionLevel: "CAN_MANAGE",
+				Inherited:       true,
+			},
+			{
+				ObjectPath:      "/Shared/team/etl",
+				ObjectType:      workspace.Notebook,
+				PrincipalName:   "admins",
+				PrincipalType:   "group",
+				PermissionLevel: "CAN_MANAGE",
+				Inherited:       true,
+			},
+			{
+				ObjectPath:      "/Shared/team/etl",
+				ObjectType:      workspace.Notebook,
+				PrincipalName:   "00000000-0000-0000-0000-000000000001",
+				PrincipalType:   "service_principal",
+				PermissionLevel: "CAN_RUN",
+			},
+		}, flat)
+	})
+}
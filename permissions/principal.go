@@ -0,0 +1,150 @@
+package permissions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/terraform-provider-databricks/scim"
+)
+
+// Possible types of a resolved principal
+const (
+	PrincipalUser             = "user"
+	PrincipalGroup            = "group"
+	PrincipalServicePrincipal = "service_principal"
+)
+
+// maxSuggestions limits the number of near-matches listed when the principal cannot be found
+const maxSuggestions = 3
+
+// Principal is a user, group or service principal that permissions can be granted to
+type Principal struct {
+	Type string
+	ID   string
+	// Name is the user name, group display name or service principal application ID
+	Name string
+}
+
+// AccessControlChange returns the change granting the permission level to the principal
+func (p Principal) AccessControlChange(permissionLevel string) AccessControlChange {
+	change := AccessControlChange{PermissionLevel: permissionLevel}
+	switch p.Type {
+	case PrincipalUser:
+		change.UserName = p.Name
+	case PrincipalGroup:
+		change.GroupName = p.Name
+	case PrincipalServicePrincipal:
+		change.ServicePrincipalName = p.Name
+	}
+	return change
+}
+
+// ResolvePrincipal finds the user with the given email, the group with the given display name or the service
+// principal with the given application ID. If nothing matches, the not found error lists the closest names
+// starting with the same characters.
+func (a PermissionsAPI) ResolvePrincipal(name string) (Principal, error) {
+	usersAPI := scim.NewUsersAPI(a.context, a.client)
+	groupsAPI := scim.NewGroupsAPI(a.context, a.client)
+	servicePrincipalsAPI := scim.NewServicePrincipalsAPI(a.context, a.client)
+	users, err := usersAPI.Filter(fmt.Sprintf(`userName eq "%s"`, name), true)
+	if err != nil {
+		return Principal{}, err
+	}
+	if len(users) > 0 {
+		return Principal{Type: PrincipalUser, ID: users[0].ID, Name: users[0].UserName}, nil
+	}
+	groups, err := groupsAPI.Filter(fmt.Sprintf(`displayName eq "%s"`, name))
+	if err != nil {
+		return Principal{}, err
+	}
+	if len(groups.Resources) > 0 {
+		group := groups.Resources[0]
+		return Principal{Type: PrincipalGroup, ID: group.ID, Name: group.DisplayName}, nil
+	}
+	servicePrincipals, err := servicePrincipalsAPI.Filter(fmt.Sprintf(`applicationId eq "%s"`, name), true)
+	if err != nil {
+		return Principal{}, err
+	}
+	if len(servicePrincipals) > 0 {
+		sp := servicePrincipals[0]
+		return Principal{Type: PrincipalServicePrincipal, ID: sp.ID, Name: sp.ApplicationID}, nil
+	}
+
+	prefix := name
+	if r := []rune(name); len(r) > 3 {
+		prefix = string(r[:3])
+	}
+	var candidates []string
+	users, err = usersAPI.Filter(fmt.Sprintf(`userName sw "%s"`, prefix), true)
+	if err != nil {
+		return Principal{}, err
+	}
+	for _, u := range users {
+		candidates = append(candidates, u.UserName)
+	}
+	groups, err = groupsAPI.Filter(fmt.Sprintf(`displayName sw "%s"`, prefix))
+	if err != nil {
+		return Principal{}, err
+	}
+	for _, g := range groups.Resources {
+		candidates = append(candidates, g.DisplayName)
+	}
+	servicePrincipals, err = servicePrincipalsAPI.Filter(fmt.Sprintf(`applicationId sw "%s"`, prefix), true)
+	if err != nil {
+		return Principal{}, err
+	}
+	for _, sp := range servicePrincipals {
+		candidates = append(candidates, sp.ApplicationID)
+	}
+	msg := fmt.Sprintf("cannot find user, group or service principal %s", name)
+	if suggestions := closestNames(name, candidates); len(suggestions) > 0 {
+		msg += fmt.Sprintf(". Did you mean: %s?", strings.Join(suggestions, ", "))
+	}
+	return Principal{}, apierr.NotFound(msg)
+}
+
+// closestNames returns up to maxSuggestions candidates ordered by edit distance to the name
+func closestNames(name string, candidates []string) []string {
+	distances := map[string]int{}
+	for _, c := range candidates {
+		distances[c] = editDistance(strings.ToLower(name), strings.ToLower(c))
+	}
+	names := []string{}
+	for c := range distances {
+		names = append(names, c)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if distances[names[i]] != distances[names[j]] {
+			return distances[names[i]] < distances[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > maxSuggestions {
+		names = names[:maxSuggestions]
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
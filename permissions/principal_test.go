@@ -0,0 +1,127 @@
+package permissions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/databricks/terraform-provider-databricks/scim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	usersFilter             = "/api/2.0/preview/scim/v2/Users?excludedAttributes=roles&filter="
+	groupsFilter            = "/api/2.0/preview/scim/v2/Groups?filter="
+	servicePrincipalsFilter = "/api/2.0/preview/scim/v2/ServicePrincipals?excludedAttributes=roles&filter="
+)
+
+func TestResolvePrincipal(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: usersFilter + "userName%20eq%20%22me%40example.com%22",
+			Response: scim.UserList{
+				Resources: []scim.User{{ID: "1", UserName: "me@example.com"}},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: usersFilter + "userName%20eq%20%22analysts%22",
+			Response: scim.UserList{},
+		},
+		{
+			Method:   "GET",
+			Resource: groupsFilter + "displayName%20eq%20%22analysts%22",
+			Response: scim.GroupList{
+				Resources: []scim.Group{{ID: "2", DisplayName: "analysts"}},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: usersFilter + "userName%20eq%20%22abc-123%22",
+			Response: scim.UserList{},
+		},
+		{
+			Method:   "GET",
+			Resource: groupsFilter + "displayName%20eq%20%22abc-123%22",
+			Response: scim.GroupList{},
+		},
+		{
+			Method:   "GET",
+			Resource: servicePrincipalsFilter + "applicationId%20eq%20%22abc-123%22",
+			Response: scim.UserList{
+				Resources: []scim.User{{ID: "3", ApplicationID: "abc-123", DisplayName: "ci"}},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewPermissionsAPI(ctx, client)
+		principal, err := a.ResolvePrincipal("me@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, Principal{Type: PrincipalUser, ID: "1", Name: "me@example.com"}, principal)
+		assert.Equal(t, AccessControlChange{UserName: "me@example.com", PermissionLevel: "CAN_READ"},
+			principal.AccessControlChange("CAN_READ"))
+
+		principal, err = a.ResolvePrincipal("analysts")
+		require.NoError(t, err)
+		assert.Equal(t, Principal{Type: PrincipalGroup, ID: "2", Name: "analysts"}, principal)
+		assert.Equal(t, AccessControlChange{GroupName: "analysts", PermissionLevel: "CAN_READ"},
+			principal.AccessControlChange("CAN_READ"))
+
+		principal, err = a.ResolvePrincipal("abc-123")
+		require.NoError(t, err)
+		assert.Equal(t, Principal{Type: PrincipalServicePrincipal, ID: "3", Name: "abc-123"}, principal)
+		assert.Equal(t, AccessControlChange{ServicePrincipalName: "abc-123", PermissionLevel: "CAN_READ"},
+			principal.AccessControlChange("CAN_READ"))
+	})
+}
+
+func TestResolvePrincipal_NotFoundWithSuggestions(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: usersFilter + "userName%20eq%20%22data-enginers%22",
+			Response: scim.UserList{},
+		},
+		{
+			Method:   "GET",
+			Resource: groupsFilter + "displayName%20eq%20%22data-enginers%22",
+			Response: scim.GroupList{},
+		},
+		{
+			Method:   "GET",
+			Resource: servicePrincipalsFilter + "applicationId%20eq%20%22data-enginers%22",
+			Response: scim.UserList{},
+		},
+		{
+			Method:   "GET",
+			Resource: usersFilter + "userName%20sw%20%22dat%22",
+			Response: scim.UserList{
+				Resources: []scim.User{{ID: "1", UserName: "dateng@example.com"}},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: groupsFilter + "displayName%20sw%20%22dat%22",
+			Response: scim.GroupList{
+				Resources: []scim.Group{
+					{ID: "2", DisplayName: "data-engineers"},
+					{ID: "3", DisplayName: "data-science"},
+					{ID: "4", DisplayName: "data-engineering-admins"},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: servicePrincipalsFilter + "applicationId%20sw%20%22dat%22",
+			Response: scim.UserList{},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		_, err := NewPermissionsAPI(ctx, client).ResolvePrincipal("data-enginers")
+		assert.True(t, apierr.IsMissing(err))
+		assert.EqualError(t, err, "cannot find user, group or service principal data-enginers. "+
+			"Did you mean: data-engineers, data-science, data-engineering-admins?")
+	})
+}
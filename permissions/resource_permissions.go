@@ -12,6 +12,7 @@ import (
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/workspace"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -253,6 +254,39 @@ func (a PermissionsAPI) Update(objectID string, objectACL AccessControlChangeLis
 	return a.safePutWithOwner(objectID, objectACL, originalAcl)
 }
 
+// CreateNotebookWithPermissions imports a notebook and applies the given access control list to it.
+// If permissions cannot be applied and rollbackOnFailure is set, the imported notebook is removed, unless
+// it has overwritten an existing notebook, which is kept.
+func (a PermissionsAPI) CreateNotebookWithPermissions(r workspace.ImportPath,
+	objectACL AccessControlChangeList, rollbackOnFailure bool) error {
+	notebooksAPI := workspace.NewNotebooksAPI(a.context, a.client)
+	existed := false
+	if r.Overwrite {
+		_, err := notebooksAPI.Read(r.Path)
+		if err != nil && !apierr.IsMissing(err) {
+			return err
+		}
+		existed = err == nil
+	}
+	err := notebooksAPI.Create(r)
+	if err != nil {
+		return err
+	}
+	status, err := notebooksAPI.Read(r.Path)
+	if err == nil {
+		err = a.Update(fmt.Sprintf("/notebooks/%d", status.ObjectID), objectACL)
+	}
+	if err == nil {
+		return nil
+	}
+	if rollbackOnFailure && !existed {
+		if deleteErr := notebooksAPI.Delete(r.Path, false); deleteErr != nil {
+			log.Printf("[ERROR] Cannot remove %s after permissions failure: %s", r.Path, deleteErr)
+		}
+	}
+	return fmt.Errorf("cannot set permissions for %s: %w", r.Path, err)
+}
+
 // Delete gracefully removes permissions. Technically, it's using method named SetOrDelete, but here we do more
 func (a PermissionsAPI) Delete(objectID string) error {
 	objectACL, err := a.Read(objectID)
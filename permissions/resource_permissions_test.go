@@ -2033,3 +2033,89 @@ func TestResourcePermissionsRootDirectory(t *testing.T) {
 	assert.Equal(t, TestingUser, firstElem["user_name"])
 	assert.Equal(t, "CAN_READ", firstElem["permission_level"])
 }
+
+func TestCreateNotebookWithPermissions(t *testing.T) {
+	notebook := workspace.ImportPath{
+		Content:   "YWJjCg==",
+		Path:      "/Shared/nb",
+		Language:  "PYTHON",
+		Format:    "SOURCE",
+		Overwrite: true,
+	}
+	acl := AccessControlChangeList{
+		AccessControlList: []AccessControlChange{
+			{
+				GroupName:       "analysts",
+				PermissionLevel: "CAN_READ",
+			},
+		},
+	}
+	notFound := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fnb",
+		Status:   404,
+		Response: common.APIErrorBody{
+			ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+			Message:   "Path (/Shared/nb) doesn't exist.",
+		},
+	}
+	found := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fnb",
+		Response: workspace.ObjectStatus{
+			ObjectID:   988765,
+			ObjectType: "NOTEBOOK",
+			Path:       "/Shared/nb",
+		},
+	}
+	rejected := qa.HTTPFixture{
+		Method:          "PUT",
+		Resource:        "/api/2.0/permissions/notebooks/988765",
+		ExpectedRequest: acl,
+		Status:          400,
+		Response: common.APIErrorBody{
+			ErrorCode: "INVALID_PARAMETER_VALUE",
+			Message:   "Group analysts does not exist",
+		},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/workspace/import",
+			ExpectedRequest: notebook,
+			ReuseRequest:    true,
+		},
+		notFound,
+		found,
+		{
+			Method:          "PUT",
+			Resource:        "/api/2.0/permissions/notebooks/988765",
+			ExpectedRequest: acl,
+		},
+		// new notebook is removed
+		notFound,
+		found,
+		rejected,
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/delete",
+			ExpectedRequest: workspace.DeletePath{
+				Path: "/Shared/nb",
+			},
+		},
+		// overwritten notebook is kept
+		found,
+		found,
+		rejected,
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		p := NewPermissionsAPI(ctx, client)
+		err := p.CreateNotebookWithPermissions(notebook, acl, true)
+		require.NoError(t, err)
+
+		err = p.CreateNotebookWithPermissions(notebook, acl, true)
+		assert.EqualError(t, err, "cannot set permissions for /Shared/nb: Group analysts does not exist")
+
+		err = p.CreateNotebookWithPermissions(notebook, acl, true)
+		assert.EqualError(t, err, "cannot set permissions for /Shared/nb: Group analysts does not exist")
+	})
+}
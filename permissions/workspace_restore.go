@@ -0,0 +1,144 @@
+package permissions
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/terraform-provider-databricks/workspace"
+)
+
+// WorkspaceObjectWithACL is a backed up notebook or directory together with its access control list
+type WorkspaceObjectWithACL struct {
+	Path       string `json:"path"`
+	ObjectType string `json:"object_type"`
+	Language   string `json:"language,omitempty"`
+	// LocalPath is the file with notebook source, relative to the backup directory
+	LocalPath         string                `json:"local_path,omitempty"`
+	AccessControlList []AccessControlChange `json:"access_control_list,omitempty"`
+}
+
+// SkippedPermission is a permission from the backup, that wasn't restored because its principal
+// no longer exists in the workspace
+type SkippedPermission struct {
+	Path   string
+	Change AccessControlChange
+}
+
+// RestoreFromBackup recreates notebooks and directories from the backup, reading notebook sources from
+// files in baseLocalDir, and reapplies their access control lists. Principals that no longer exist in the
+// workspace are skipped, so that the rest of the restore can proceed, and their permissions are returned.
+func (a PermissionsAPI) RestoreFromBackup(backup []WorkspaceObjectWithACL, baseLocalDir string) ([]SkippedPermission, error) {
+	notebooksAPI := workspace.NewNotebooksAPI(a.context, a.client)
+	skipped := []SkippedPermission{}
+	for _, object := range backup {
+		var resourceType string
+		switch object.ObjectType {
+		case workspace.Directory:
+			resourceType = "directories"
+			err := notebooksAPI.Mkdirs(object.Path)
+			if err != nil {
+				return skipped, fmt.Errorf("cannot create directory %s: %w", object.Path, err)
+			}
+		case workspace.Notebook:
+			resourceType = "notebooks"
+			err := restoreNotebook(notebooksAPI, object, baseLocalDir)
+			if err != nil {
+				return skipped, fmt.Errorf("cannot restore notebook %s: %w", object.Path, err)
+			}
+		default:
+			return skipped, fmt.Errorf("cannot restore %s: unsupported object type %s", object.Path, object.ObjectType)
+		}
+		if len(object.AccessControlList) == 0 {
+			continue
+		}
+		status, err := notebooksAPI.Read(object.Path)
+		if err != nil {
+			return skipped, err
+		}
+		missing, err := a.updateSkippingMissingPrincipals(fmt.Sprintf("/%s/%d", resourceType, status.ObjectID),
+			object.Path, object.AccessControlList)
+		for _, change := range missing {
+			skipped = append(skipped, SkippedPermission{
+				Path:   object.Path,
+				Change: change,
+			})
+		}
+		if err != nil {
+			return skipped, fmt.Errorf("cannot set permissions for %s: %w", object.Path, err)
+		}
+	}
+	return skipped, nil
+}
+
+func restoreNotebook(notebooksAPI workspace.NotebooksAPI, object WorkspaceObjectWithACL, baseLocalDir string) error {
+	content, err := os.ReadFile(filepath.Join(baseLocalDir, object.LocalPath))
+	if err != nil {
+		return err
+	}
+	err = notebooksAPI.Mkdirs(path.Dir(object.Path))
+	if err != nil {
+		return err
+	}
+	return notebooksAPI.Create(workspace.ImportPath{
+		Content:   base64.StdEncoding.EncodeToString(content),
+		Path:      object.Path,
+		Language:  object.Language,
+		Format:    "SOURCE",
+		Overwrite: true,
+	})
+}
+
+// updateSkippingMissingPrincipals applies the access control list, removing the principals
+// that the workspace reports as non-existent and trying again with the rest. Removed changes are returned.
+func (a PermissionsAPI) updateSkippingMissingPrincipals(objectID, objectPath string,
+	acl []AccessControlChange) ([]AccessControlChange, error) {
+	skipped := []AccessControlChange{}
+	for {
+		err := a.Update(objectID, AccessControlChangeList{
+			AccessControlList: acl,
+		})
+		var apiErr *apierr.APIError
+		if err == nil || !errors.As(err, &apiErr) || !strings.Contains(apiErr.Message, "does not exist") {
+			return skipped, err
+		}
+		remaining := []AccessControlChange{}
+		for _, change := range acl {
+			name := change.UserName + change.GroupName + change.ServicePrincipalName
+			if mentionsPrincipal(apiErr.Message, name) {
+				log.Printf("[WARN] Skipping %s permission on %s for missing principal %s",
+					change.PermissionLevel, objectPath, name)
+				skipped = append(skipped, change)
+				continue
+			}
+			remaining = append(remaining, change)
+		}
+		if len(remaining) == len(acl) {
+			return skipped, err
+		}
+		if len(remaining) == 0 {
+			return skipped, nil
+		}
+		acl = remaining
+	}
+}
+
+// mentionsPrincipal returns true if the error message names the principal in parentheses or quotes,
+// like in "Principal: UserName(someone@example.com) does not exist"
+func mentionsPrincipal(message, name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, quoted := range []string{"(" + name + ")", "'" + name + "'", `"` + name + `"`} {
+		if strings.Contains(message, quoted) {
+			return true
+		}
+	}
+	return false
+}
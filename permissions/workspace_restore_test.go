@@ -0,0 +1,133 @@
+package permissions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/databricks/terraform-provider-databricks/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreFromBackup(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "etl.py"), []byte("print(1)"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.sql"), []byte("SELECT 1"), 0600))
+	alice := AccessControlChange{
+		UserName:        "alice@example.com",
+		PermissionLevel: "CAN_RUN",
+	}
+	ghost := AccessControlChange{
+		UserName:        "ghost@example.com",
+		PermissionLevel: "CAN_EDIT",
+	}
+	// name of existing user is a part of the missing one
+	host := AccessControlChange{
+		UserName:        "host@example.com",
+		PermissionLevel: "CAN_READ",
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/mkdirs",
+			ExpectedRequest: map[string]string{
+				"path": "/Shared/team",
+			},
+			ReuseRequest: true,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/import",
+			ExpectedRequest: workspace.ImportPath{
+				Content:   "cHJpbnQoMSk=",
+				Path:      "/Shared/team/etl",
+				Language:  workspace.Python,
+				Format:    "SOURCE",
+				Overwrite: true,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fteam%2Fetl",
+			Response: workspace.ObjectStatus{
+				ObjectID:   1,
+				ObjectType: workspace.Notebook,
+				Path:       "/Shared/team/etl",
+			},
+		},
+		{
+			Method:   "PUT",
+			Resource: "/api/2.0/permissions/notebooks/1",
+			ExpectedRequest: AccessControlChangeList{
+				AccessControlList: []AccessControlChange{alice},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/import",
+			ExpectedRequest: workspace.ImportPath{
+				Content:   "U0VMRUNUIDE=",
+				Path:      "/Shared/team/report",
+				Language:  workspace.SQL,
+				Format:    "SOURCE",
+				Overwrite: true,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fteam%2Freport",
+			Response: workspace.ObjectStatus{
+				ObjectID:   2,
+				ObjectType: workspace.Notebook,
+				Path:       "/Shared/team/report",
+			},
+		},
+		{
+			Method:   "PUT",
+			Resource: "/api/2.0/permissions/notebooks/2",
+			ExpectedRequest: AccessControlChangeList{
+				AccessControlList: []AccessControlChange{alice, ghost, host},
+			},
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message:   "Principal: UserName(ghost@example.com) does not exist",
+			},
+			Status: 400,
+		},
+		{
+			Method:   "PUT",
+			Resource: "/api/2.0/permissions/notebooks/2",
+			ExpectedRequest: AccessControlChangeList{
+				AccessControlList: []AccessControlChange{alice, host},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		skipped, err := NewPermissionsAPI(ctx, client).RestoreFromBackup([]WorkspaceObjectWithACL{
+			{
+				Path:              "/Shared/team/etl",
+				ObjectType:        workspace.Notebook,
+				Language:          workspace.Python,
+				LocalPath:         "etl.py",
+				AccessControlList: []AccessControlChange{alice},
+			},
+			{
+				Path:              "/Shared/team/report",
+				ObjectType:        workspace.Notebook,
+				Language:          workspace.SQL,
+				LocalPath:         "report.sql",
+				AccessControlList: []AccessControlChange{alice, ghost, host},
+			},
+		}, dir)
+		require.NoError(t, err)
+		assert.Equal(t, []SkippedPermission{
+			{
+				Path:   "/Shared/team/report",
+				Change: ghost,
+			},
+		}, skipped)
+	})
+}
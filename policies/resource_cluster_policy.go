@@ -2,6 +2,8 @@ package policies
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/service/compute"
@@ -11,9 +13,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// ListPolicyFamilies returns all policy families available in the workspace, which policies
+// can inherit from with policy_family_id
+func ListPolicyFamilies(ctx context.Context, w *databricks.WorkspaceClient) ([]compute.PolicyFamily, error) {
+	return w.PolicyFamilies.ListAll(ctx, compute.ListPolicyFamiliesRequest{})
+}
+
 func isBuiltinPolicyFamily(ctx context.Context, w *databricks.WorkspaceClient, familyId, familyName string) (bool, error) {
 	// Fetch supported policy families, and check against it
-	families, err2 := w.PolicyFamilies.ListAll(ctx, compute.ListPolicyFamiliesRequest{})
+	families, err2 := ListPolicyFamilies(ctx, w)
 	if err2 != nil {
 		return false, err2
 	}
@@ -25,6 +33,46 @@ func isBuiltinPolicyFamily(ctx context.Context, w *databricks.WorkspaceClient, f
 	return false, nil
 }
 
+// NormalizePolicyDefinition parses the JSON policy definition and serializes it back
+// with stable key ordering and without insignificant whitespace
+func NormalizePolicyDefinition(def string) (string, error) {
+	var v any
+	if err := json.Unmarshal([]byte(def), &v); err != nil {
+		return "", fmt.Errorf("invalid policy definition: %w", err)
+	}
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+// suppressEquivalentPolicyDefinition ignores differences in formatting and key ordering
+// of JSON policy definitions
+func suppressEquivalentPolicyDefinition(k, old, new string, d *schema.ResourceData) bool {
+	normalizedOld, err := NormalizePolicyDefinition(old)
+	if err != nil {
+		return common.SuppressDiffWhitespaceChange(k, old, new, d)
+	}
+	normalizedNew, err := NormalizePolicyDefinition(new)
+	if err != nil {
+		return common.SuppressDiffWhitespaceChange(k, old, new, d)
+	}
+	return normalizedOld == normalizedNew
+}
+
+func validatePolicyDefinitions(definitions ...string) error {
+	for _, def := range definitions {
+		if def == "" {
+			continue
+		}
+		if _, err := NormalizePolicyDefinition(def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 var rcpSchema = common.StructToSchema(
 	compute.CreatePolicy{},
 	func(m map[string]*schema.Schema) map[string]*schema.Schema {
@@ -34,10 +82,10 @@ var rcpSchema = common.StructToSchema(
 		}
 		m["definition"].ConflictsWith = []string{"policy_family_definition_overrides", "policy_family_id"}
 		m["definition"].Computed = true
-		m["definition"].DiffSuppressFunc = common.SuppressDiffWhitespaceChange
+		m["definition"].DiffSuppressFunc = suppressEquivalentPolicyDefinition
 
 		m["policy_family_definition_overrides"].ConflictsWith = []string{"definition"}
-		m["policy_family_definition_overrides"].DiffSuppressFunc = common.SuppressDiffWhitespaceChange
+		m["policy_family_definition_overrides"].DiffSuppressFunc = suppressEquivalentPolicyDefinition
 		m["policy_family_id"].ConflictsWith = []string{"definition"}
 		m["policy_family_definition_overrides"].RequiredWith = []string{"policy_family_id"}
 
@@ -64,6 +112,10 @@ func ResourceClusterPolicy() common.Resource {
 
 			var request compute.CreatePolicy
 			common.DataToStructPointer(d, rcpSchema, &request)
+			err = validatePolicyDefinitions(request.Definition, request.PolicyFamilyDefinitionOverrides)
+			if err != nil {
+				return err
+			}
 
 			var clusterPolicy *compute.CreatePolicyResponse
 			if request.PolicyFamilyId != "" {
@@ -116,6 +168,10 @@ func ResourceClusterPolicy() common.Resource {
 			if request.PolicyFamilyId != "" {
 				request.Definition = ""
 			}
+			err = validatePolicyDefinitions(request.Definition, request.PolicyFamilyDefinitionOverrides)
+			if err != nil {
+				return err
+			}
 
 			return w.ClusterPolicies.Edit(ctx, request)
 		},
@@ -1,12 +1,14 @@
 package policies
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceClusterPolicyRead(t *testing.T) {
@@ -540,3 +542,84 @@ func TestResourceClusterPolicyDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestNormalizePolicyDefinition(t *testing.T) {
+	a, err := NormalizePolicyDefinition(`{"spark_conf.foo": {"type": "fixed", "value": "bar"}, "autotermination_minutes": {"type": "fixed", "value": 10}}`)
+	require.NoError(t, err)
+	b, err := NormalizePolicyDefinition(`{
+		"autotermination_minutes": {"value": 10, "type": "fixed"},
+		"spark_conf.foo": {"value": "bar", "type": "fixed"}
+	}`)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+	assert.True(t, suppressEquivalentPolicyDefinition("definition",
+		`{"a": {"type": "fixed", "value": 1}}`,
+		`{"a":{"value":1,"type":"fixed"}}`, nil))
+	assert.False(t, suppressEquivalentPolicyDefinition("definition",
+		`{"a": {"type": "fixed", "value": 1}}`,
+		`{"a": {"type": "fixed", "value": 2}}`, nil))
+
+	_, err = NormalizePolicyDefinition(`{"a": `)
+	assert.EqualError(t, err, "invalid policy definition: unexpected end of JSON input")
+}
+
+func TestResourceClusterPolicyCreate_InvalidDefinition(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		State: map[string]any{
+			"definition": `{"spark_conf.foo": }`,
+			"name":       "Dummy",
+		},
+		Create: true,
+	}.ExpectError(t, "invalid policy definition: invalid character '}' looking for beginning of value")
+}
+
+func TestResourceClusterPolicyCreateFromPolicyFamily_InvalidOverrides(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		State: map[string]any{
+			"policy_family_definition_overrides": `{"spark_conf.foo": {"type": "fixed"`,
+			"name":                               "Dummy",
+			"policy_family_id":                   "personal-vm",
+		},
+		Create: true,
+	}.ExpectError(t, "invalid policy definition: unexpected end of JSON input")
+}
+
+func TestListPolicyFamilies(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/policy-families?",
+			Response: compute.ListPolicyFamiliesResponse{
+				PolicyFamilies: []compute.PolicyFamily{
+					{
+						Name:           "Personal Compute",
+						PolicyFamilyId: "personal-vm",
+					},
+				},
+				NextPageToken: "next",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/policy-families?page_token=next",
+			Response: compute.ListPolicyFamiliesResponse{
+				PolicyFamilies: []compute.PolicyFamily{
+					{
+						Name:           "Job Compute",
+						PolicyFamilyId: "job-cluster",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		families, err := ListPolicyFamilies(ctx, w)
+		require.NoError(t, err)
+		require.Len(t, families, 2)
+		assert.Equal(t, "personal-vm", families[0].PolicyFamilyId)
+		assert.Equal(t, "Job Compute", families[1].Name)
+	})
+}
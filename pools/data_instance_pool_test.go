@@ -42,6 +42,40 @@ func TestDataSourceInstancePool(t *testing.T) {
 	assert.Equal(t, "node-type", d.Get("pool_info.0.node_type_id").(string))
 }
 
+func TestDataSourceInstancePool_DuplicateNames(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Response: InstancePoolList{
+					InstancePools: []InstancePoolAndStats{
+						{
+							InstancePoolID:   "abc",
+							InstancePoolName: "pool",
+							NodeTypeID:       "node-type",
+						},
+						{
+							InstancePoolID:   "bcd",
+							InstancePoolName: "pool",
+							NodeTypeID:       "other-node-type",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceInstancePool(),
+		ID:          ".",
+		State: map[string]any{
+			"name": "pool",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
 func TestDataSourceInstancePoolsGetPool(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
@@ -2,6 +2,7 @@ package pools
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/databricks/terraform-provider-databricks/clusters"
@@ -90,6 +91,25 @@ type InstancePool struct {
 	PreloadedDockerImages              []clusters.DockerImage          `json:"preloaded_docker_images,omitempty" tf:"force_new,slice_set,alias:preloaded_docker_image"`
 }
 
+// ValidateEdit returns an error if the edit API would reject the change of the existing pool: node type
+// cannot be changed and the pool cannot keep more idle instances than its capacity. Node type is not compared
+// when it is empty on either of the pools.
+func (ip InstancePool) ValidateEdit(old InstancePool) error {
+	if ip.NodeTypeID != "" && old.NodeTypeID != "" && ip.NodeTypeID != old.NodeTypeID {
+		return fmt.Errorf("cannot edit instance pool %s: node_type_id cannot be changed from %s to %s. "+
+			"Recreate the pool to change it", old.InstancePoolID, old.NodeTypeID, ip.NodeTypeID)
+	}
+	return ip.validateCapacity()
+}
+
+func (ip InstancePool) validateCapacity() error {
+	if ip.MaxCapacity > 0 && ip.MinIdleInstances > ip.MaxCapacity {
+		return fmt.Errorf("cannot edit instance pool %s: min_idle_instances (%d) must not be greater "+
+			"than max_capacity (%d)", ip.InstancePoolID, ip.MinIdleInstances, ip.MaxCapacity)
+	}
+	return nil
+}
+
 // InstancePoolStats contains the stats on a given pool
 type InstancePoolStats struct {
 	UsedCount        int32 `json:"used_count,omitempty"`
@@ -156,12 +176,65 @@ func (a InstancePoolsAPI) Read(instancePoolID string) (ip InstancePool, err erro
 	return
 }
 
+// Utilization returns the number of used, idle and pending instances of the pool
+func (a InstancePoolsAPI) Utilization(instancePoolID string) (InstancePoolStats, error) {
+	var pool InstancePoolAndStats
+	err := a.client.Get(a.context, "/instance-pools/get", map[string]string{
+		"instance_pool_id": instancePoolID,
+	}, &pool)
+	if err != nil {
+		return InstancePoolStats{}, err
+	}
+	if pool.Stats == nil {
+		return InstancePoolStats{}, nil
+	}
+	return *pool.Stats, nil
+}
+
 // List retrieves the list of existing instance pools
 func (a InstancePoolsAPI) List() (ipl InstancePoolList, err error) {
 	err = a.client.Get(a.context, "/instance-pools/list", nil, &ipl)
 	return
 }
 
+// Orphaned returns instance pools that have no used, idle or pending instances, so that they could be
+// deleted by cleanup jobs
+func (a InstancePoolsAPI) Orphaned() ([]InstancePoolAndStats, error) {
+	poolList, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+	orphaned := []InstancePoolAndStats{}
+	for _, pool := range poolList.InstancePools {
+		if pool.Stats == nil || *pool.Stats == (InstancePoolStats{}) {
+			orphaned = append(orphaned, pool)
+		}
+	}
+	return orphaned, nil
+}
+
+// GetByName retrieves the instance pool with the given name, failing if there are none or several of them
+func (a InstancePoolsAPI) GetByName(name string) (InstancePoolAndStats, error) {
+	poolList, err := a.List()
+	if err != nil {
+		return InstancePoolAndStats{}, err
+	}
+	var matches []InstancePoolAndStats
+	for _, pool := range poolList.InstancePools {
+		if pool.InstancePoolName == name {
+			matches = append(matches, pool)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return InstancePoolAndStats{}, fmt.Errorf("instance pool '%s' doesn't exist", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return InstancePoolAndStats{}, fmt.Errorf("there are %d instance pools named '%s'", len(matches), name)
+	}
+}
+
 // Delete terminates a instance pool given its ID
 func (a InstancePoolsAPI) Delete(instancePoolID string) error {
 	return a.client.Post(a.context, "/instance-pools/delete", map[string]string{
@@ -169,6 +242,34 @@ func (a InstancePoolsAPI) Delete(instancePoolID string) error {
 	}, nil)
 }
 
+// DependentClusters returns names of clusters that take worker or driver instances from the pool
+func (a InstancePoolsAPI) DependentClusters(instancePoolID string) ([]string, error) {
+	clusterList, err := clusters.NewClustersAPI(a.context, a.client).List()
+	if err != nil {
+		return nil, err
+	}
+	dependents := []string{}
+	for _, cluster := range clusterList {
+		if cluster.InstancePoolID == instancePoolID || cluster.DriverInstancePoolID == instancePoolID {
+			dependents = append(dependents, cluster.ClusterName)
+		}
+	}
+	return dependents, nil
+}
+
+// DeleteIfUnused deletes the instance pool, unless there are clusters that depend on it
+func (a InstancePoolsAPI) DeleteIfUnused(instancePoolID string) error {
+	dependents, err := a.DependentClusters(instancePoolID)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return fmt.Errorf("cannot delete instance pool %s, because it's used by %d clusters: %s",
+			instancePoolID, len(dependents), strings.Join(dependents, ", "))
+	}
+	return a.Delete(instancePoolID)
+}
+
 // ResourceInstancePool ...
 func ResourceInstancePool() common.Resource {
 	s := common.StructToSchema(InstancePool{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
@@ -271,6 +372,14 @@ func ResourceInstancePool() common.Resource {
 			var ip InstancePool
 			common.DataToStructPointer(d, s, &ip)
 			ip.InstancePoolID = d.Id()
+			oldNodeTypeID, _ := d.GetChange("node_type_id")
+			err := ip.ValidateEdit(InstancePool{
+				InstancePoolID: d.Id(),
+				NodeTypeID:     oldNodeTypeID.(string),
+			})
+			if err != nil {
+				return err
+			}
 			return NewInstancePoolsAPI(ctx, c).Update(ip)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -1,11 +1,14 @@
 package pools
 
 import (
+	"context"
 	"testing"
 
+	"github.com/databricks/terraform-provider-databricks/clusters"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceInstancePoolCreate(t *testing.T) {
@@ -230,6 +233,151 @@ func TestResourceInstancePoolUpdate_Error(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestInstancePoolValidateEdit(t *testing.T) {
+	old := InstancePool{
+		InstancePoolID:                     "abc",
+		InstancePoolName:                   "Pool",
+		NodeTypeID:                         "i3.xlarge",
+		MaxCapacity:                        10,
+		IdleInstanceAutoTerminationMinutes: 10,
+		EnableElasticDisk:                  true,
+	}
+	edited := old
+	edited.IdleInstanceAutoTerminationMinutes = 30
+	assert.NoError(t, edited.ValidateEdit(old))
+
+	edited = old
+	edited.NodeTypeID = "i3.2xlarge"
+	assert.EqualError(t, edited.ValidateEdit(old), "cannot edit instance pool abc: "+
+		"node_type_id cannot be changed from i3.xlarge to i3.2xlarge. Recreate the pool to change it")
+
+	edited = old
+	edited.MinIdleInstances = 20
+	assert.EqualError(t, edited.ValidateEdit(old), "cannot edit instance pool abc: "+
+		"min_idle_instances (20) must not be greater than max_capacity (10)")
+}
+
+func TestResourceInstancePoolUpdate_DockerBasicAuth(t *testing.T) {
+	dockerImage := clusters.DockerImage{
+		URL: "databricksruntime/standard:latest",
+		BasicAuth: &clusters.DockerBasicAuth{
+			Username: "user",
+			Password: "secret",
+		},
+	}
+	// only min_idle_instances changes, so the pool is edited without comparing the docker image,
+	// which the API returns without the basic auth password
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/edit",
+				ExpectedRequest: InstancePool{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Docker Pool",
+					NodeTypeID:                         "i3.xlarge",
+					MinIdleInstances:                   2,
+					MaxCapacity:                        10,
+					IdleInstanceAutoTerminationMinutes: 20,
+					EnableElasticDisk:                  true,
+					PreloadedDockerImages:              []clusters.DockerImage{dockerImage},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Docker Pool",
+					NodeTypeID:                         "i3.xlarge",
+					MinIdleInstances:                   2,
+					MaxCapacity:                        10,
+					IdleInstanceAutoTerminationMinutes: 20,
+					EnableElasticDisk:                  true,
+					PreloadedDockerImages:              []clusters.DockerImage{dockerImage},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Docker Pool"
+		node_type_id = "i3.xlarge"
+		min_idle_instances = 2
+		max_capacity = 10
+		idle_instance_autotermination_minutes = 20
+		preloaded_docker_image {
+			url = "databricksruntime/standard:latest"
+			basic_auth {
+				username = "user"
+				password = "secret"
+			}
+		}
+		`,
+		InstanceState: map[string]string{
+			"instance_pool_name":                                      "Docker Pool",
+			"node_type_id":                                            "i3.xlarge",
+			"min_idle_instances":                                      "0",
+			"max_capacity":                                            "10",
+			"idle_instance_autotermination_minutes":                   "20",
+			"enable_elastic_disk":                                     "true",
+			"preloaded_docker_image.#":                                "1",
+			"preloaded_docker_image.3199835615.url":                   "databricksruntime/standard:latest",
+			"preloaded_docker_image.3199835615.basic_auth.#":          "1",
+			"preloaded_docker_image.3199835615.basic_auth.0.username": "user",
+			"preloaded_docker_image.3199835615.basic_auth.0.password": "secret",
+		},
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err)
+}
+
+func TestResourceInstancePoolUpdate_MinIdleOverCapacity(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Pool"
+		node_type_id = "i3.xlarge"
+		min_idle_instances = 20
+		max_capacity = 10
+		idle_instance_autotermination_minutes = 20
+		`,
+		InstanceState: map[string]string{
+			"instance_pool_name":                    "Pool",
+			"node_type_id":                          "i3.xlarge",
+			"min_idle_instances":                    "0",
+			"max_capacity":                          "10",
+			"idle_instance_autotermination_minutes": "20",
+			"enable_elastic_disk":                   "true",
+		},
+		Update: true,
+		ID:     "abc",
+	}.ExpectError(t, "cannot edit instance pool abc: min_idle_instances (20) must not be greater than max_capacity (10)")
+}
+
+func TestResourceInstancePoolUpdate_NodeTypeChanged(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Pool"
+		node_type_id = "i3.2xlarge"
+		max_capacity = 10
+		idle_instance_autotermination_minutes = 20
+		`,
+		InstanceState: map[string]string{
+			"instance_pool_name":                    "Pool",
+			"node_type_id":                          "i3.xlarge",
+			"max_capacity":                          "10",
+			"idle_instance_autotermination_minutes": "20",
+			"enable_elastic_disk":                   "true",
+		},
+		Update:      true,
+		RequiresNew: true,
+		ID:          "abc",
+	}.ExpectError(t, "cannot edit instance pool abc: node_type_id cannot be changed from i3.xlarge to i3.2xlarge. "+
+		"Recreate the pool to change it")
+}
+
 func TestResourceInstancePoolDelete(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -269,3 +417,184 @@ func TestResourceInstancePoolDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestInstancePoolsUtilization(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+			Response: map[string]any{
+				"instance_pool_id":   "abc",
+				"instance_pool_name": "Shared Pool",
+				"stats": map[string]any{
+					"used_count":         3,
+					"idle_count":         2,
+					"pending_used_count": 1,
+					"pending_idle_count": 4,
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		stats, err := NewInstancePoolsAPI(ctx, client).Utilization("abc")
+		require.NoError(t, err)
+		assert.Equal(t, InstancePoolStats{
+			UsedCount:        3,
+			IdleCount:        2,
+			PendingUsedCount: 1,
+			PendingIdleCount: 4,
+		}, stats)
+	})
+}
+
+func TestInstancePoolsOrphaned(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/list",
+			Response: InstancePoolList{
+				InstancePools: []InstancePoolAndStats{
+					{
+						InstancePoolID: "used",
+						Stats: &InstancePoolStats{
+							UsedCount: 2,
+						},
+					},
+					{
+						InstancePoolID: "idle",
+						Stats: &InstancePoolStats{
+							IdleCount: 1,
+						},
+					},
+					{
+						InstancePoolID: "pending",
+						Stats: &InstancePoolStats{
+							PendingUsedCount: 1,
+						},
+					},
+					{
+						InstancePoolID: "empty",
+						Stats:          &InstancePoolStats{},
+					},
+					{
+						InstancePoolID: "no-stats",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		orphaned, err := NewInstancePoolsAPI(ctx, client).Orphaned()
+		require.NoError(t, err)
+		var ids []string
+		for _, pool := range orphaned {
+			ids = append(ids, pool.InstancePoolID)
+		}
+		assert.Equal(t, []string{"empty", "no-stats"}, ids)
+	})
+}
+
+func TestInstancePoolsDependentClusters(t *testing.T) {
+	clusterList := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.1/clusters/list",
+		Response: clusters.ClusterList{
+			Clusters: []clusters.ClusterInfo{
+				{
+					ClusterName:          "workers",
+					InstancePoolID:       "abc",
+					DriverInstancePoolID: "abc",
+				},
+				{
+					ClusterName:          "driver",
+					InstancePoolID:       "def",
+					DriverInstancePoolID: "abc",
+				},
+				{
+					ClusterName: "unrelated",
+					NodeTypeID:  "i3.xlarge",
+				},
+			},
+		},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		clusterList,
+		clusterList,
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		instancePoolsAPI := NewInstancePoolsAPI(ctx, client)
+		dependents, err := instancePoolsAPI.DependentClusters("abc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"workers", "driver"}, dependents)
+
+		err = instancePoolsAPI.DeleteIfUnused("abc")
+		assert.EqualError(t, err, "cannot delete instance pool abc, because it's used by 2 clusters: workers, driver")
+	})
+}
+
+func TestInstancePoolsDeleteIfUnused(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/list",
+			Response: clusters.ClusterList{
+				Clusters: []clusters.ClusterInfo{
+					{
+						ClusterName:    "other",
+						InstancePoolID: "def",
+					},
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/instance-pools/delete",
+			ExpectedRequest: map[string]string{
+				"instance_pool_id": "abc",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewInstancePoolsAPI(ctx, client).DeleteIfUnused("abc")
+		require.NoError(t, err)
+	})
+}
+
+func TestInstancePoolsGetByName(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/instance-pools/list",
+			ReuseRequest: true,
+			Response: InstancePoolList{
+				InstancePools: []InstancePoolAndStats{
+					{
+						InstancePoolID:   "abc",
+						InstancePoolName: "Shared Pool",
+						NodeTypeID:       "i3.xlarge",
+					},
+					{
+						InstancePoolID:   "bcd",
+						InstancePoolName: "Other Pool",
+					},
+					{
+						InstancePoolID:   "cde",
+						InstancePoolName: "Duplicate",
+					},
+					{
+						InstancePoolID:   "def",
+						InstancePoolName: "Duplicate",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewInstancePoolsAPI(ctx, client)
+		pool, err := a.GetByName("Shared Pool")
+		require.NoError(t, err)
+		assert.Equal(t, "abc", pool.InstancePoolID)
+		assert.Equal(t, "i3.xlarge", pool.NodeTypeID)
+
+		_, err = a.GetByName("Missing")
+		assert.EqualError(t, err, "instance pool 'Missing' doesn't exist")
+
+		_, err = a.GetByName("Duplicate")
+		assert.EqualError(t, err, "there are 2 instance pools named 'Duplicate'")
+	})
+}
@@ -127,6 +127,24 @@ func (a ReposAPI) ListAll() ([]ReposInformation, error) {
 	return a.List("")
 }
 
+// RepoForPath returns the Repo containing the given workspace path and whether there is such Repo
+func (a ReposAPI) RepoForPath(objectPath string) (ReposInformation, bool, error) {
+	parts := strings.Split(strings.TrimPrefix(objectPath, "/"), "/")
+	if len(parts) < 3 || parts[0] != "Repos" {
+		return ReposInformation{}, false, nil
+	}
+	repos, err := a.List("/" + path.Join(parts[0], parts[1]))
+	if err != nil {
+		return ReposInformation{}, false, err
+	}
+	for _, repo := range repos {
+		if objectPath == repo.Path || strings.HasPrefix(objectPath, repo.Path+"/") {
+			return repo, true, nil
+		}
+	}
+	return ReposInformation{}, false, nil
+}
+
 var (
 	gitProvidersMap = map[string]string{
 		"github.com":    "gitHub",
@@ -521,3 +521,42 @@ func TestReposListWithPrefix(t *testing.T) {
 	assert.Equal(t, len(reposList), 1)
 	assert.Equal(t, resp.Branch, reposList[0].Branch)
 }
+
+func TestReposAPIRepoForPath(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       http.MethodGet,
+			Resource:     "/api/2.0/repos?path_prefix=%2FRepos%2Fsomeone%40example.com",
+			ReuseRequest: true,
+			Response: ReposListResponse{
+				Repos: []ReposInformation{
+					{
+						ID:     123,
+						Path:   "/Repos/someone@example.com/repo",
+						Branch: "main",
+					},
+					{
+						ID:     234,
+						Path:   "/Repos/someone@example.com/repo-two",
+						Branch: "dev",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewReposAPI(ctx, client)
+		repo, ok, err := a.RepoForPath("/Repos/someone@example.com/repo-two/etl/ingest")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, int64(234), repo.ID)
+		assert.Equal(t, "dev", repo.Branch)
+
+		_, ok, err = a.RepoForPath("/Repos/someone@example.com/other/notebook")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		_, ok, err = a.RepoForPath("/Users/someone@example.com/notebook")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,170 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+// defaultBulkMaxOperations is used when the workspace supports bulk requests, but doesn't report the limit
+const defaultBulkMaxOperations = 100
+
+// BulkOperation is a single create, update or delete within a bulk request
+type BulkOperation struct {
+	Method string `json:"method"`
+	BulkID string `json:"bulkId,omitempty"`
+	// Path is relative to the SCIM API root, like /Users or /Groups/123
+	Path string `json:"path"`
+	Data any    `json:"data,omitempty"`
+}
+
+// BulkOperationResult is the outcome of a single operation within a bulk request
+type BulkOperationResult struct {
+	Method   string          `json:"method,omitempty"`
+	BulkID   string          `json:"bulkId,omitempty"`
+	Location string          `json:"location,omitempty"`
+	Status   string          `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// Failed returns true if the operation didn't succeed
+func (r BulkOperationResult) Failed() bool {
+	status, err := strconv.Atoi(r.Status)
+	return err != nil || status >= 400
+}
+
+// BulkResponse contains results of all operations, in the order of the request
+type BulkResponse struct {
+	Schemas    []URN                 `json:"schemas,omitempty"`
+	Operations []BulkOperationResult `json:"Operations"`
+}
+
+type bulkRequest struct {
+	Schemas    []URN           `json:"schemas"`
+	Operations []BulkOperation `json:"Operations"`
+}
+
+type serviceProviderConfig struct {
+	Bulk struct {
+		Supported     bool `json:"supported"`
+		MaxOperations int  `json:"maxOperations,omitempty"`
+	} `json:"bulk"`
+}
+
+// NewBulkAPI creates BulkAPI instance from provider meta
+func NewBulkAPI(ctx context.Context, m any) BulkAPI {
+	return BulkAPI{
+		client:  m.(*common.DatabricksClient),
+		context: ctx,
+	}
+}
+
+// BulkAPI exposes the scim bulk API
+type BulkAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Bulk sends the operations in as few bulk requests as the workspace allows and returns the results of all of
+// them. When the workspace doesn't support bulk requests, every operation is sent as a separate request.
+// Failures of individual operations are reported in their results and don't stop the rest.
+func (a BulkAPI) Bulk(operations []BulkOperation) (BulkResponse, error) {
+	response := BulkResponse{
+		Schemas:    []URN{BulkResponseSchema},
+		Operations: []BulkOperationResult{},
+	}
+	maxOperations, err := a.maxOperations()
+	if err != nil {
+		return response, err
+	}
+	operations = append([]BulkOperation{}, operations...)
+	for i, op := range operations {
+		if op.Method == http.MethodPost && op.BulkID == "" {
+			operations[i].BulkID = fmt.Sprintf("bulk-%d", i)
+		}
+	}
+	for len(operations) > 0 && maxOperations > 0 {
+		chunk := operations[:min(maxOperations, len(operations))]
+		var chunkResponse BulkResponse
+		err = a.client.Scim(a.context, http.MethodPost, "/preview/scim/v2/Bulk", bulkRequest{
+			Schemas:    []URN{BulkRequestSchema},
+			Operations: chunk,
+		}, &chunkResponse)
+		if apierr.IsMissing(err) {
+			log.Printf("[INFO] SCIM bulk endpoint is not available, sending operations one by one")
+			break
+		}
+		if err != nil {
+			return response, err
+		}
+		response.Operations = append(response.Operations, chunkResponse.Operations...)
+		operations = operations[len(chunk):]
+	}
+	for _, op := range operations {
+		response.Operations = append(response.Operations, a.single(op))
+	}
+	return response, nil
+}
+
+// maxOperations returns the number of operations allowed in a single bulk request, or 0 if bulk requests
+// are not supported
+func (a BulkAPI) maxOperations() (int, error) {
+	var config serviceProviderConfig
+	err := a.client.Scim(a.context, http.MethodGet, "/preview/scim/v2/ServiceProviderConfig", nil, &config)
+	if apierr.IsMissing(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !config.Bulk.Supported {
+		return 0, nil
+	}
+	if config.Bulk.MaxOperations <= 0 {
+		return defaultBulkMaxOperations, nil
+	}
+	return config.Bulk.MaxOperations, nil
+}
+
+// single sends the operation as a separate request and converts the outcome into the bulk operation result
+func (a BulkAPI) single(op BulkOperation) BulkOperationResult {
+	result := BulkOperationResult{
+		Method: op.Method,
+		BulkID: op.BulkID,
+	}
+	var body json.RawMessage
+	err := a.client.Scim(a.context, op.Method, "/preview/scim/v2"+op.Path, op.Data, &body)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var apiErr *apierr.APIError
+		if errors.As(err, &apiErr) {
+			status = apiErr.StatusCode
+		}
+		result.Status = strconv.Itoa(status)
+		result.Response, _ = json.Marshal(map[string]any{
+			"schemas": []URN{ErrorSchema},
+			"status":  result.Status,
+			"detail":  err.Error(),
+		})
+		return result
+	}
+	result.Status = strconv.Itoa(http.StatusOK)
+	result.Location = op.Path
+	result.Response = body
+	if op.Method == http.MethodPost {
+		result.Status = strconv.Itoa(http.StatusCreated)
+		var created struct {
+			ID string `json:"id"`
+		}
+		_ = json.Unmarshal(body, &created)
+		result.Location = fmt.Sprintf("%s/%s", op.Path, created.ID)
+	}
+	return result
+}
@@ -0,0 +1,157 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkCreateUsers(userNames ...string) []BulkOperation {
+	operations := []BulkOperation{}
+	for _, userName := range userNames {
+		operations = append(operations, BulkOperation{
+			Method: http.MethodPost,
+			Path:   "/Users",
+			Data: User{
+				Schemas:  []URN{UserSchema},
+				UserName: userName,
+			},
+		})
+	}
+	return operations
+}
+
+func TestBulkCreateUsers(t *testing.T) {
+	created := func(bulkID, id string) BulkOperationResult {
+		return BulkOperationResult{
+			Method:   http.MethodPost,
+			BulkID:   bulkID,
+			Location: "/Users/" + id,
+			Status:   "201",
+		}
+	}
+	operations := bulkCreateUsers("a@example.com", "b@example.com", "c@example.com")
+	for i := range operations {
+		operations[i].BulkID = []string{"bulk-0", "bulk-1", "bulk-2"}[i]
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/ServiceProviderConfig",
+			Response: `{"bulk": {"supported": true, "maxOperations": 2}}`,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/preview/scim/v2/Bulk",
+			ExpectedRequest: bulkRequest{
+				Schemas:    []URN{BulkRequestSchema},
+				Operations: operations[:2],
+			},
+			Response: BulkResponse{
+				Schemas: []URN{BulkResponseSchema},
+				Operations: []BulkOperationResult{
+					created("bulk-0", "1"),
+					{
+						Method: http.MethodPost,
+						BulkID: "bulk-1",
+						Status: "409",
+					},
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/preview/scim/v2/Bulk",
+			ExpectedRequest: bulkRequest{
+				Schemas:    []URN{BulkRequestSchema},
+				Operations: operations[2:],
+			},
+			Response: BulkResponse{
+				Schemas: []URN{BulkResponseSchema},
+				Operations: []BulkOperationResult{
+					created("bulk-2", "3"),
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		response, err := NewBulkAPI(ctx, client).Bulk(bulkCreateUsers(
+			"a@example.com", "b@example.com", "c@example.com"))
+		require.NoError(t, err)
+		require.Len(t, response.Operations, 3)
+		assert.Equal(t, created("bulk-0", "1"), response.Operations[0])
+		assert.True(t, response.Operations[1].Failed())
+		assert.Equal(t, created("bulk-2", "3"), response.Operations[2])
+	})
+}
+
+func TestBulkCreateUsers_NotSupported(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/ServiceProviderConfig",
+			Response: `{"bulk": {"supported": false}}`,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/preview/scim/v2/Users",
+			ExpectedRequest: User{
+				Schemas:  []URN{UserSchema},
+				UserName: "a@example.com",
+			},
+			Response: User{
+				ID:       "1",
+				UserName: "a@example.com",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/preview/scim/v2/Users",
+			ExpectedRequest: User{
+				Schemas:  []URN{UserSchema},
+				UserName: "b@example.com",
+			},
+			Status: 409,
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_ALREADY_EXISTS",
+				Message:   "User already exists",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/preview/scim/v2/Users",
+			ExpectedRequest: User{
+				Schemas:  []URN{UserSchema},
+				UserName: "c@example.com",
+			},
+			Response: User{
+				ID:       "3",
+				UserName: "c@example.com",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		response, err := NewBulkAPI(ctx, client).Bulk(bulkCreateUsers(
+			"a@example.com", "b@example.com", "c@example.com"))
+		require.NoError(t, err)
+		require.Len(t, response.Operations, 3)
+		assert.Equal(t, "/Users/1", response.Operations[0].Location)
+		assert.Equal(t, "201", response.Operations[0].Status)
+		var user User
+		require.NoError(t, json.Unmarshal(response.Operations[0].Response, &user))
+		assert.Equal(t, "a@example.com", user.UserName)
+
+		assert.Equal(t, "409", response.Operations[1].Status)
+		assert.True(t, response.Operations[1].Failed())
+		assert.Contains(t, string(response.Operations[1].Response), "User already exists")
+
+		assert.Equal(t, "bulk-2", response.Operations[2].BulkID)
+		assert.Equal(t, "/Users/3", response.Operations[2].Location)
+		assert.False(t, response.Operations[2].Failed())
+	})
+}
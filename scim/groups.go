@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 )
@@ -20,6 +21,14 @@ func NewGroupsAPI(ctx context.Context, m any) GroupsAPI {
 type GroupsAPI struct {
 	client  *common.DatabricksClient
 	context context.Context
+
+	readRetryTimeout time.Duration
+}
+
+// WithReadRetry returns the API that retries reads of groups that are not found yet for up to the given timeout
+func (a GroupsAPI) WithReadRetry(timeout time.Duration) GroupsAPI {
+	a.readRetryTimeout = timeout
+	return a
 }
 
 // Create creates a scim group in the Databricks workspace
@@ -29,16 +38,88 @@ func (a GroupsAPI) Create(scimGroupRequest Group) (group Group, err error) {
 	return
 }
 
-// Read reads and returns a Group object via SCIM api
-func (a GroupsAPI) Read(groupID, attributes string) (group Group, err error) {
-	err = a.client.Scim(a.context, http.MethodGet, fmt.Sprintf(
-		"/preview/scim/v2/Groups/%v?attributes=%s", groupID, attributes), nil, &group)
+// CreateWithMembers creates a group with the given members and entitlements in a single request. If the
+// created group doesn't report some of them back, the missing ones are added with a follow-up PATCH.
+func (a GroupsAPI) CreateWithMembers(name string, memberIDs []string, entitlementValues []string) (Group, error) {
+	request := Group{
+		DisplayName: name,
+	}
+	for _, id := range memberIDs {
+		request.Members = append(request.Members, ComplexValue{Value: id})
+	}
+	for _, e := range entitlementValues {
+		request.Entitlements = append(request.Entitlements, ComplexValue{Value: e})
+	}
+	group, err := a.Create(request)
 	if err != nil {
-		return
+		return group, err
+	}
+	var operations []patchOperation
+	missingMembers := missingComplexValues(group.Members, request.Members)
+	if len(missingMembers) > 0 {
+		operations = append(operations, patchOperation{Op: "add", Path: "members", Value: missingMembers})
+	}
+	missingEntitlements := missingComplexValues(ComplexValues(group.Entitlements), request.Entitlements)
+	if len(missingEntitlements) > 0 {
+		operations = append(operations, patchOperation{Op: "add", Path: "entitlements", Value: missingEntitlements})
+	}
+	if len(operations) == 0 {
+		return group, nil
+	}
+	err = a.Patch(group.ID, PatchRequestComplexValue(operations))
+	if err != nil {
+		return group, fmt.Errorf("group %s is created, but cannot add members and entitlements: %w", group.ID, err)
+	}
+	group.Members = append(group.Members, missingMembers...)
+	group.Entitlements = append(group.Entitlements, missingEntitlements...)
+	return group, nil
+}
+
+func missingComplexValues(actual ComplexValues, expected []ComplexValue) (missing []ComplexValue) {
+	for _, v := range expected {
+		if !actual.HasValue(v.Value) {
+			missing = append(missing, v)
+		}
 	}
 	return
 }
 
+// Read reads and returns a Group object via SCIM api
+func (a GroupsAPI) Read(groupID, attributes string) (Group, error) {
+	return retryOnNotFound(a.context, a.readRetryTimeout, func() (group Group, err error) {
+		err = a.client.Scim(a.context, http.MethodGet, fmt.Sprintf(
+			"/preview/scim/v2/Groups/%v?attributes=%s", groupID, attributes), nil, &group)
+		return
+	})
+}
+
+// Possible sources of a group returned by GroupsAPI.Source
+const (
+	GroupSourceAccount   = "account"
+	GroupSourceWorkspace = "workspace"
+)
+
+// Source returns GroupSourceAccount if the group is synced from the account, or GroupSourceWorkspace
+// if it's local to the workspace, based on the resource type in the SCIM metadata of the group
+func (a GroupsAPI) Source(groupID string) (string, error) {
+	group, err := a.Read(groupID, "id,meta")
+	if err != nil {
+		return "", err
+	}
+	if group.Meta == nil {
+		return "", fmt.Errorf("cannot determine source of group %s: SCIM metadata is missing", groupID)
+	}
+	switch group.Meta.ResourceType {
+	case "Group":
+		return GroupSourceAccount, nil
+	case "WorkspaceGroup":
+		return GroupSourceWorkspace, nil
+	default:
+		return "", fmt.Errorf("cannot determine source of group %s: unknown resource type %q",
+			groupID, group.Meta.ResourceType)
+	}
+}
+
 // Filter returns groups matching the filter
 func (a GroupsAPI) Filter(filter string) (GroupList, error) {
 	var groups GroupList
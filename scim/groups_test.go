@@ -0,0 +1,107 @@
+package scim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupsCreateWithMembers(t *testing.T) {
+	members := []ComplexValue{{Value: "1"}, {Value: "2"}, {Value: "3"}}
+	entitlements := []ComplexValue{{Value: "allow-cluster-create"}, {Value: "databricks-sql-access"}}
+	request := Group{
+		Schemas:      []URN{GroupSchema},
+		DisplayName:  "analysts",
+		Members:      members,
+		Entitlements: entitlements,
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/preview/scim/v2/Groups",
+			ExpectedRequest: request,
+			Response: Group{
+				ID:           "abc",
+				DisplayName:  "analysts",
+				Members:      members,
+				Entitlements: entitlements,
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/preview/scim/v2/Groups",
+			ExpectedRequest: request,
+			Response: Group{
+				ID:           "bcd",
+				DisplayName:  "analysts",
+				Entitlements: entitlements,
+			},
+		},
+		{
+			Method:   "PATCH",
+			Resource: "/api/2.0/preview/scim/v2/Groups/bcd",
+			ExpectedRequest: PatchRequestComplexValue([]patchOperation{
+				{Op: "add", Path: "members", Value: members},
+			}),
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewGroupsAPI(ctx, client)
+		group, err := a.CreateWithMembers("analysts", []string{"1", "2", "3"},
+			[]string{"allow-cluster-create", "databricks-sql-access"})
+		require.NoError(t, err)
+		assert.Equal(t, "abc", group.ID)
+		assert.Len(t, group.Members, 3)
+		assert.Len(t, group.Entitlements, 2)
+
+		group, err = a.CreateWithMembers("analysts", []string{"1", "2", "3"},
+			[]string{"allow-cluster-create", "databricks-sql-access"})
+		require.NoError(t, err)
+		assert.Equal(t, "bcd", group.ID)
+		assert.Len(t, group.Members, 3)
+	})
+}
+
+func TestGroupsSource(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=id,meta",
+			Response: Group{
+				ID:   "abc",
+				Meta: &Meta{ResourceType: "Group"},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups/bcd?attributes=id,meta",
+			Response: Group{
+				ID:   "bcd",
+				Meta: &Meta{ResourceType: "WorkspaceGroup"},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups/cde?attributes=id,meta",
+			Response: Group{
+				ID: "cde",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewGroupsAPI(ctx, client)
+		source, err := a.Source("abc")
+		require.NoError(t, err)
+		assert.Equal(t, GroupSourceAccount, source)
+
+		source, err = a.Source("bcd")
+		require.NoError(t, err)
+		assert.Equal(t, GroupSourceWorkspace, source)
+
+		_, err = a.Source("cde")
+		assert.EqualError(t, err, "cannot determine source of group cde: SCIM metadata is missing")
+	})
+}
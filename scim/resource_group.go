@@ -35,6 +35,7 @@ func ResourceGroup() common.Resource {
 			return m
 		})
 	addEntitlementsToSchema(groupSchema)
+	created := &createdIDs{}
 	return common.Resource{
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			g := Group{
@@ -48,10 +49,16 @@ func ResourceGroup() common.Resource {
 				return createForceOverridesManuallyAddedGroup(err, d, groupsAPI, g)
 			}
 			d.SetId(group.ID)
+			created.add(group.ID)
 			return nil
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			group, err := NewGroupsAPI(ctx, c).Read(d.Id(), "displayName,externalId,entitlements")
+			groupsAPI := NewGroupsAPI(ctx, c)
+			if created.take(d.Id()) {
+				// newly created groups take a while to propagate
+				groupsAPI = groupsAPI.WithReadRetry(DefaultReadRetryTimeout)
+			}
+			group, err := groupsAPI.Read(d.Id(), "displayName,externalId,entitlements")
 			if err != nil {
 				return err
 			}
@@ -76,6 +76,54 @@ func TestResourceGroupCreate(t *testing.T) {
 	assert.Equal(t, "groups/Data Scientists", d.Get("acl_principal_id"))
 }
 
+func TestResourceGroupCreate_NotPropagatedYet(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Groups",
+				ExpectedRequest: Group{
+					Schemas:     []URN{GroupSchema},
+					DisplayName: "Data Scientists",
+					Entitlements: []ComplexValue{
+						{
+							Value: "",
+						},
+					},
+				},
+				Response: Group{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=displayName,externalId,entitlements",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Group abc not found",
+				},
+				Status: 404,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=displayName,externalId,entitlements",
+				Response: Group{
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		HCL: `
+		display_name = "Data Scientists"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "Data Scientists", d.Get("display_name"))
+}
+
 func TestResourceGroupCreate_Error(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -172,6 +220,7 @@ func TestResourceGroupRead_NotFound(t *testing.T) {
 			},
 		},
 		Resource: ResourceGroup(),
+		New:      true,
 		Read:     true,
 		Removed:  true,
 		ID:       "abc",
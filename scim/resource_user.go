@@ -81,6 +81,7 @@ func ResourceUser() common.Resource {
 			ExternalID:   u.ExternalID,
 		}, nil
 	}
+	created := &createdIDs{}
 	return common.Resource{
 		Schema: userSchema,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -94,10 +95,16 @@ func ResourceUser() common.Resource {
 				return createForceOverridesManuallyAddedUser(err, d, usersAPI, u)
 			}
 			d.SetId(user.ID)
+			created.add(user.ID)
 			return nil
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			user, err := NewUsersAPI(ctx, c).Read(d.Id(), userAttributes)
+			usersAPI := NewUsersAPI(ctx, c)
+			if created.take(d.Id()) {
+				// newly created users take a while to propagate
+				usersAPI = usersAPI.WithReadRetry(DefaultReadRetryTimeout)
+			}
+			user, err := usersAPI.Read(d.Id(), userAttributes)
 			if err != nil {
 				return err
 			}
@@ -141,8 +148,7 @@ func ResourceUser() common.Resource {
 			}
 			// Disable or delete
 			if isDisable {
-				r := PatchRequestWithValue("replace", "active", "false")
-				err = user.Patch(d.Id(), r)
+				err = user.SetActive(d.Id(), false)
 			} else {
 				err = user.Delete(d.Id())
 			}
@@ -155,6 +155,56 @@ func TestResourceUserCreate(t *testing.T) {
 	assert.Equal(t, "/Repos/me@example.com", d.Get("repos"))
 }
 
+func TestResourceUserCreate_NotPropagatedYet(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Users",
+				ExpectedRequest: User{
+					Active:   true,
+					UserName: "me@example.com",
+					Schemas:  []URN{UserSchema},
+					Entitlements: entitlements{
+						{
+							Value: "",
+						},
+					},
+				},
+				Response: User{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName,displayName,active,externalId,entitlements",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "User abc not found",
+				},
+				Status: 404,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName,displayName,active,externalId,entitlements",
+				Response: User{
+					Active:   true,
+					UserName: "me@example.com",
+					ID:       "abc",
+				},
+			},
+		},
+		Resource: ResourceUser(),
+		Create:   true,
+		HCL: `
+		user_name = "me@example.com"
+		`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "me@example.com", d.Get("user_name"))
+}
+
 func TestResourceUserCreateInactive(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -1,9 +1,52 @@
 package scim
 
 import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/retries"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// DefaultReadRetryTimeout is the window for retrying reads of users and groups right after they are
+// created, as newly created entities take a while to propagate
+const DefaultReadRetryTimeout = 2 * time.Minute
+
+// createdIDs remembers the IDs of entities created by a resource until they are read for the first time,
+// so that only the read right after the creation waits for them to propagate, but not the read of an import
+type createdIDs struct {
+	ids sync.Map
+}
+
+func (c *createdIDs) add(id string) {
+	c.ids.Store(id, struct{}{})
+}
+
+// take returns true only for the first read of the created entity
+func (c *createdIDs) take(id string) bool {
+	_, ok := c.ids.LoadAndDelete(id)
+	return ok
+}
+
+// retryOnNotFound retries the read while it fails with not found error, up to the given timeout
+func retryOnNotFound[T any](ctx context.Context, timeout time.Duration, read func() (T, error)) (T, error) {
+	if timeout <= 0 {
+		return read()
+	}
+	r := retries.New[T](retries.WithTimeout(timeout), retries.OnErrors(apierr.ErrNotFound))
+	v, err := r.Run(ctx, func(ctx context.Context) (*T, error) {
+		v, err := read()
+		return &v, err
+	})
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+	return *v, nil
+}
+
 // URN is a custom type for the SCIM spec for the schema
 type URN string
 
@@ -14,6 +57,9 @@ const (
 	WorkspaceUserSchema    URN = "urn:ietf:params:scim:schemas:extension:workspace:2.0:User"
 	PatchOp                URN = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
 	GroupSchema            URN = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	BulkRequestSchema      URN = "urn:ietf:params:scim:api:messages:2.0:BulkRequest"
+	BulkResponseSchema     URN = "urn:ietf:params:scim:api:messages:2.0:BulkResponse"
+	ErrorSchema            URN = "urn:ietf:params:scim:api:messages:2.0:Error"
 )
 
 // Generalisation of most common complex values from SCIM protocol
@@ -112,6 +158,12 @@ type Group struct {
 	Roles        []ComplexValue `json:"roles,omitempty"`
 	Entitlements entitlements   `json:"entitlements,omitempty"`
 	ExternalID   string         `json:"externalId,omitempty"`
+	Meta         *Meta          `json:"meta,omitempty"`
+}
+
+// Meta contains the SCIM metadata of a resource
+type Meta struct {
+	ResourceType string `json:"resourceType,omitempty"`
 }
 
 // GroupList contains a list of groups fetched from a list api call from SCIM api
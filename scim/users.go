@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 )
@@ -20,6 +22,14 @@ func NewUsersAPI(ctx context.Context, m any) UsersAPI {
 type UsersAPI struct {
 	client  *common.DatabricksClient
 	context context.Context
+
+	readRetryTimeout time.Duration
+}
+
+// WithReadRetry returns the API that retries reads of users that are not found yet for up to the given timeout
+func (a UsersAPI) WithReadRetry(timeout time.Duration) UsersAPI {
+	a.readRetryTimeout = timeout
+	return a
 }
 
 // Create user in the backend
@@ -52,7 +62,9 @@ func (a UsersAPI) Filter(filter string, excludeRoles bool) (u []User, err error)
 
 func (a UsersAPI) Read(userID, attributes string) (User, error) {
 	userPath := fmt.Sprintf("/preview/scim/v2/Users/%v?attributes=%s", userID, attributes)
-	return a.readByPath(userPath)
+	return retryOnNotFound(a.context, a.readRetryTimeout, func() (User, error) {
+		return a.readByPath(userPath)
+	})
 }
 
 // Me gets user information about caller
@@ -86,6 +98,11 @@ func (a UsersAPI) Patch(userID string, r patchRequest) error {
 	return a.client.Scim(a.context, http.MethodPatch, fmt.Sprintf("/preview/scim/v2/Users/%v", userID), r, nil)
 }
 
+// SetActive activates or deactivates the user, preserving it for audit purposes
+func (a UsersAPI) SetActive(userID string, active bool) error {
+	return a.Patch(userID, PatchRequestWithValue("replace", "active", strconv.FormatBool(active)))
+}
+
 // Delete will delete the user given the user id
 func (a UsersAPI) Delete(userID string) error {
 	userPath := fmt.Sprintf("/preview/scim/v2/Users/%v", userID)
@@ -96,3 +113,19 @@ func (a UsersAPI) UpdateEntitlements(userID string, entitlements patchRequest) e
 	return a.client.Scim(a.context, http.MethodPatch,
 		fmt.Sprintf("/preview/scim/v2/Users/%v", userID), entitlements, nil)
 }
+
+// Groups returns summaries of groups the user is a direct member of
+func (a UsersAPI) Groups(userID string) ([]Group, error) {
+	user, err := a.Read(userID, "groups")
+	if err != nil {
+		return nil, err
+	}
+	groups := []Group{}
+	for _, g := range user.Groups {
+		groups = append(groups, Group{
+			ID:          g.Value,
+			DisplayName: g.Display,
+		})
+	}
+	return groups, nil
+}
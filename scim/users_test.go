@@ -3,7 +3,9 @@ package scim
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 
 	"github.com/stretchr/testify/assert"
@@ -40,3 +42,131 @@ func TestUsersFilter(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, users, 0)
 }
+
+func TestUsersGroups(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=groups",
+			Response: User{
+				ID: "abc",
+				Groups: []ComplexValue{
+					{
+						Value:   "1",
+						Display: "admins",
+					},
+					{
+						Value:   "2",
+						Display: "analysts",
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Users/bcd?attributes=groups",
+			Response: User{
+				ID: "bcd",
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	usersAPI := NewUsersAPI(context.Background(), client)
+	groups, err := usersAPI.Groups("abc")
+	require.NoError(t, err)
+	assert.Equal(t, []Group{
+		{ID: "1", DisplayName: "admins"},
+		{ID: "2", DisplayName: "analysts"},
+	}, groups)
+
+	groups, err = usersAPI.Groups("bcd")
+	require.NoError(t, err)
+	assert.Len(t, groups, 0)
+}
+
+func TestUsersSetActive(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:          "PATCH",
+			Resource:        "/api/2.0/preview/scim/v2/Users/abc",
+			ExpectedRequest: PatchRequestWithValue("replace", "active", "false"),
+		},
+		{
+			Method:          "PATCH",
+			Resource:        "/api/2.0/preview/scim/v2/Users/bcd",
+			ExpectedRequest: PatchRequestWithValue("replace", "active", "true"),
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	usersAPI := NewUsersAPI(context.Background(), client)
+	err = usersAPI.SetActive("abc", false)
+	assert.NoError(t, err)
+	err = usersAPI.SetActive("bcd", true)
+	assert.NoError(t, err)
+}
+
+func TestUsersAndGroupsUseAccountSCIM(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/00000000-0000-0000-0000-000000000001/scim/v2/Users/abc?attributes=userName",
+			Response: User{
+				ID:       "abc",
+				UserName: "someone@example.com",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/00000000-0000-0000-0000-000000000001/scim/v2/Groups/bcd?attributes=displayName",
+			Response: Group{
+				ID:          "bcd",
+				DisplayName: "analysts",
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	client.Config.WithTesting().AccountID = "00000000-0000-0000-0000-000000000001"
+	ctx := context.Background()
+
+	user, err := NewUsersAPI(ctx, client).Read("abc", "userName")
+	require.NoError(t, err)
+	assert.Equal(t, "someone@example.com", user.UserName)
+
+	group, err := NewGroupsAPI(ctx, client).Read("bcd", "displayName")
+	require.NoError(t, err)
+	assert.Equal(t, "analysts", group.DisplayName)
+}
+
+func TestUsersReadRetriesNotFound(t *testing.T) {
+	notFound := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName",
+		Response: common.APIErrorBody{
+			ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+			Message:   "User abc not found",
+		},
+		Status: 404,
+	}
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		notFound,
+		notFound,
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName",
+			Response: User{
+				ID:       "abc",
+				UserName: "someone@example.com",
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	user, err := NewUsersAPI(context.Background(), client).
+		WithReadRetry(time.Minute).
+		Read("abc", "userName")
+	require.NoError(t, err)
+	assert.Equal(t, "someone@example.com", user.UserName)
+}
@@ -0,0 +1,54 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+)
+
+// ScopeInventory describes a secret scope together with metadata of its secrets and ACLs.
+// Secret values are never included.
+type ScopeInventory struct {
+	Scope       string                     `json:"scope"`
+	BackendType workspace.ScopeBackendType `json:"backend_type,omitempty"`
+	Secrets     []workspace.SecretMetadata `json:"secrets,omitempty"`
+	ACLs        []workspace.AclItem        `json:"acls,omitempty"`
+}
+
+// Inventory lists all secret scopes with their secret keys and ACLs. Failures for individual
+// scopes don't stop the listing: the inventory of the remaining scopes is returned together
+// with an error joining all per-scope failures.
+func Inventory(ctx context.Context, w *databricks.WorkspaceClient) ([]ScopeInventory, error) {
+	scopes, err := w.Secrets.ListScopesAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	inventory := []ScopeInventory{}
+	for _, scope := range scopes {
+		secrets, err := w.Secrets.ListSecretsAll(ctx, workspace.ListSecretsRequest{
+			Scope: scope.Name,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot list secrets in %s: %w", scope.Name, err))
+			continue
+		}
+		acls, err := w.Secrets.ListAclsAll(ctx, workspace.ListAclsRequest{
+			Scope: scope.Name,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot list ACLs in %s: %w", scope.Name, err))
+			continue
+		}
+		inventory = append(inventory, ScopeInventory{
+			Scope:       scope.Name,
+			BackendType: scope.BackendType,
+			Secrets:     secrets,
+			ACLs:        acls,
+		})
+	}
+	return inventory, errors.Join(errs...)
+}
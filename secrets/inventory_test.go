@@ -0,0 +1,109 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventory(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/secrets/scopes/list",
+			Response: workspace.ListScopesResponse{
+				Scopes: []workspace.SecretScope{
+					{
+						Name:        "first",
+						BackendType: "DATABRICKS",
+					},
+					{
+						Name:        "second",
+						BackendType: "DATABRICKS",
+					},
+					{
+						Name:        "broken",
+						BackendType: "DATABRICKS",
+					},
+				},
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/secrets/list?scope=first",
+			Response: workspace.ListSecretsResponse{
+				Secrets: []workspace.SecretMetadata{
+					{
+						Key:                  "a",
+						LastUpdatedTimestamp: 12345678,
+					},
+				},
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/secrets/acls/list?scope=first",
+			Response: workspace.ListAclsResponse{
+				Items: []workspace.AclItem{
+					{
+						Principal:  "users",
+						Permission: "READ",
+					},
+				},
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/secrets/list?scope=second",
+			Response: workspace.ListSecretsResponse{
+				Secrets: []workspace.SecretMetadata{
+					{
+						Key: "b",
+					},
+					{
+						Key: "c",
+					},
+				},
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/secrets/acls/list?scope=second",
+			Response: workspace.ListAclsResponse{
+				Items: []workspace.AclItem{
+					{
+						Principal:  "admins",
+						Permission: "MANAGE",
+					},
+				},
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/secrets/list?scope=broken",
+			Status:   403,
+			Response: common.APIErrorBody{
+				ErrorCode: "PERMISSION_DENIED",
+				Message:   "no access",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		inventory, err := Inventory(ctx, w)
+		assert.EqualError(t, err, "cannot list secrets in broken: no access")
+		require.Len(t, inventory, 2)
+		assert.Equal(t, "first", inventory[0].Scope)
+		assert.Equal(t, "a", inventory[0].Secrets[0].Key)
+		assert.Equal(t, "users", inventory[0].ACLs[0].Principal)
+		assert.Equal(t, "second", inventory[1].Scope)
+		assert.Len(t, inventory[1].Secrets, 2)
+		assert.Equal(t, workspace.AclPermission("MANAGE"), inventory[1].ACLs[0].Permission)
+	})
+}
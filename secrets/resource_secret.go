@@ -2,7 +2,9 @@ package secrets
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"os"
 
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/apierr"
@@ -32,6 +34,27 @@ func readSecret(ctx context.Context, w *databricks.WorkspaceClient, scope string
 		fmt.Sprintf("no secret Scope found with secret metadata scope name: %s and key: %s", scope, key))
 }
 
+// maxSecretSize is the maximum size of a secret value accepted by the Secrets API
+const maxSecretSize = 128 * 1024
+
+// PutSecretFromFile stores the binary contents of a local file, like a certificate or a keystore,
+// as the secret value
+func PutSecretFromFile(ctx context.Context, w *databricks.WorkspaceClient, scope, key, filePath string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if len(content) > maxSecretSize {
+		return fmt.Errorf("%s is %d bytes, which exceeds the secret size limit of %d bytes",
+			filePath, len(content), maxSecretSize)
+	}
+	return w.Secrets.PutSecret(ctx, workspace.PutSecret{
+		Scope:      scope,
+		Key:        key,
+		BytesValue: base64.StdEncoding.EncodeToString(content),
+	})
+}
+
 // ResourceSecret manages secrets
 func ResourceSecret() common.Resource {
 	p := common.NewPairSeparatedID("scope", "key", "|||")
@@ -3,13 +3,37 @@ package secrets
 import (
 	"context"
 
+	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/service/workspace"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// PutACL grants the principal READ, WRITE or MANAGE permission on the secret scope. Other values are
+// rejected before the request is sent.
+func PutACL(ctx context.Context, w *databricks.WorkspaceClient, scope, principal string,
+	permission workspace.AclPermission) error {
+	var valid workspace.AclPermission
+	if err := valid.Set(string(permission)); err != nil {
+		return err
+	}
+	return w.Secrets.PutAcl(ctx, workspace.PutAcl{
+		Scope:      scope,
+		Principal:  principal,
+		Permission: valid,
+	})
+}
+
+// ListACLs returns principals with their permissions on the secret scope
+func ListACLs(ctx context.Context, w *databricks.WorkspaceClient, scope string) ([]workspace.AclItem, error) {
+	return w.Secrets.ListAclsAll(ctx, workspace.ListAclsRequest{
+		Scope: scope,
+	})
+}
+
 // ResourceSecretACL manages access to secret scopes
 func ResourceSecretACL() common.Resource {
 	p := common.NewPairSeparatedID("scope", "principal", "|||")
@@ -29,6 +53,11 @@ func ResourceSecretACL() common.Resource {
 			Type:     schema.TypeString,
 			Required: true,
 			ForceNew: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(workspace.AclPermissionRead),
+				string(workspace.AclPermissionWrite),
+				string(workspace.AclPermissionManage),
+			}, false),
 		},
 	}
 	return common.Resource{
@@ -40,7 +69,7 @@ func ResourceSecretACL() common.Resource {
 			}
 			var req workspace.PutAcl
 			common.DataToStructPointer(d, s, &req)
-			err = w.Secrets.PutAcl(ctx, req)
+			err = PutACL(ctx, w, req.Scope, req.Principal, req.Permission)
 			if err != nil {
 				return err
 			}
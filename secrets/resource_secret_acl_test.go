@@ -1,12 +1,14 @@
 package secrets
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceSecretACLRead(t *testing.T) {
@@ -201,3 +203,66 @@ func TestResourceSecretACLDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "global|||something", d.Id())
 }
+
+func TestPutACL(t *testing.T) {
+	permissions := []workspace.AclPermission{
+		workspace.AclPermissionRead,
+		workspace.AclPermissionWrite,
+		workspace.AclPermissionManage,
+	}
+	fixtures := []qa.HTTPFixture{}
+	for _, permission := range permissions {
+		fixtures = append(fixtures, qa.HTTPFixture{
+			Method:   "POST",
+			Resource: "/api/2.0/secrets/acls/put",
+			ExpectedRequest: workspace.PutAcl{
+				Scope:      "global",
+				Principal:  "users",
+				Permission: permission,
+			},
+		})
+	}
+	qa.HTTPFixturesApply(t, fixtures, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		for _, permission := range permissions {
+			assert.NoError(t, PutACL(ctx, w, "global", "users", permission))
+		}
+		err = PutACL(ctx, w, "global", "users", "ADMIN")
+		assert.EqualError(t, err, `value "ADMIN" is not one of "MANAGE", "READ", "WRITE"`)
+	})
+}
+
+func TestListACLs(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/secrets/acls/list?scope=global",
+			Response: workspace.ListAclsResponse{
+				Items: []workspace.AclItem{
+					{Principal: "admins", Permission: "MANAGE"},
+					{Principal: "users", Permission: "READ"},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		acls, err := ListACLs(ctx, w, "global")
+		require.NoError(t, err)
+		require.Len(t, acls, 2)
+		assert.Equal(t, workspace.AclPermissionManage, acls[0].Permission)
+		assert.Equal(t, workspace.AclPermissionRead, acls[1].Permission)
+	})
+}
+
+func TestResourceSecretACLCreate_InvalidPermission(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceSecretACL(),
+		HCL: `
+		scope = "global"
+		principal = "users"
+		permission = "manage"`,
+		Create: true,
+	}.ExpectError(t, "invalid config supplied. [permission] expected permission to be one of [READ WRITE MANAGE], got manage")
+}
@@ -2,6 +2,7 @@ package secrets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 
@@ -54,6 +55,35 @@ func readSecretScope(ctx context.Context, w *databricks.WorkspaceClient, scopeNa
 		fmt.Sprintf("no Secret Scope found with scope name %s", scopeName))
 }
 
+// CreateScopeIfNotExists creates a Databricks-backed secret scope, doing nothing if a scope
+// with the same name already exists
+func CreateScopeIfNotExists(ctx context.Context, w *databricks.WorkspaceClient, scope, initialManagePrincipal string) error {
+	_, err := readSecretScope(ctx, w, scope)
+	if err == nil {
+		return nil
+	}
+	if !apierr.IsMissing(err) {
+		return err
+	}
+	err = w.Secrets.CreateScope(ctx, workspace.CreateScope{
+		Scope:                  scope,
+		InitialManagePrincipal: initialManagePrincipal,
+	})
+	if errors.Is(err, apierr.ErrResourceAlreadyExists) {
+		return nil
+	}
+	return err
+}
+
+// ScopeBackendType returns the backend of the secret scope, which is either DATABRICKS or AZURE_KEYVAULT
+func ScopeBackendType(ctx context.Context, w *databricks.WorkspaceClient, scope string) (workspace.ScopeBackendType, error) {
+	secretScope, err := readSecretScope(ctx, w, scope)
+	if err != nil {
+		return "", err
+	}
+	return secretScope.ScopeBackendType, nil
+}
+
 var validScope = validation.StringMatch(regexp.MustCompile(`^[\w\.@_/-]{1,128}$`),
 	"Must consist of alphanumeric characters, dashes, underscores, and periods, "+
 		"and may not exceed 128 characters.")
@@ -1,9 +1,11 @@
 package secrets
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
+	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
@@ -301,3 +303,76 @@ func TestResourceSecretScopeDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestCreateScopeIfNotExists(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/secrets/scopes/list",
+			Response: workspace.ListScopesResponse{
+				Scopes: []workspace.SecretScope{
+					{
+						Name:        "existing",
+						BackendType: "DATABRICKS",
+					},
+				},
+			},
+			ReuseRequest: true,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/secrets/scopes/create",
+			ExpectedRequest: map[string]string{
+				"scope":                    "new",
+				"initial_manage_principal": "users",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		err = CreateScopeIfNotExists(ctx, w, "existing", "users")
+		assert.NoError(t, err)
+		err = CreateScopeIfNotExists(ctx, w, "new", "users")
+		assert.NoError(t, err)
+	})
+}
+
+func TestScopeBackendType(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/secrets/scopes/list",
+			Response: workspace.ListScopesResponse{
+				Scopes: []workspace.SecretScope{
+					{
+						Name:        "native",
+						BackendType: "DATABRICKS",
+					},
+					{
+						Name:        "vault",
+						BackendType: "AZURE_KEYVAULT",
+						KeyvaultMetadata: &workspace.AzureKeyVaultSecretScopeMetadata{
+							DnsName:    "https://vault.vault.azure.net/",
+							ResourceId: "/subscriptions/a/resourceGroups/b/providers/Microsoft.KeyVault/vaults/vault",
+						},
+					},
+				},
+			},
+			ReuseRequest: true,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		backend, err := ScopeBackendType(ctx, w, "native")
+		require.NoError(t, err)
+		assert.Equal(t, workspace.ScopeBackendTypeDatabricks, backend)
+
+		backend, err = ScopeBackendType(ctx, w, "vault")
+		require.NoError(t, err)
+		assert.Equal(t, workspace.ScopeBackendTypeAzureKeyvault, backend)
+
+		_, err = ScopeBackendType(ctx, w, "missing")
+		assert.True(t, apierr.IsMissing(err))
+		assert.EqualError(t, err, "no Secret Scope found with scope name missing")
+	})
+}
@@ -1,12 +1,16 @@
 package secrets
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceSecretRead(t *testing.T) {
@@ -184,3 +188,33 @@ func TestResourceSecretDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "foo|||bar", d.Id())
 }
+
+func TestPutSecretFromFile(t *testing.T) {
+	dir := t.TempDir()
+	keystore := filepath.Join(dir, "keystore.jks")
+	err := os.WriteFile(keystore, []byte{0xfe, 0xed, 0xfe, 0xed, 0x00, 0x02}, 0600)
+	require.NoError(t, err)
+	tooLarge := filepath.Join(dir, "large.bin")
+	err = os.WriteFile(tooLarge, make([]byte, maxSecretSize+1), 0600)
+	require.NoError(t, err)
+
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/secrets/put",
+			ExpectedRequest: workspace.PutSecret{
+				Scope:      "certs",
+				Key:        "keystore",
+				BytesValue: "/u3+7QAC",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		err = PutSecretFromFile(ctx, w, "certs", "keystore", keystore)
+		assert.NoError(t, err)
+
+		err = PutSecretFromFile(ctx, w, "certs", "large", tooLarge)
+		assert.ErrorContains(t, err, "exceeds the secret size limit of 131072 bytes")
+	})
+}
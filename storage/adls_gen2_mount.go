@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,6 +47,28 @@ func (m AzureADLSGen2Mount) Config(client *common.DatabricksClient) map[string]s
 	}
 }
 
+// MountADLSGen2 mounts the ADLS Gen2 container to /mnt/<mountName> on the given running cluster,
+// authenticating with the service principal. Client secret is read from the secret scope on
+// the cluster and never leaves it. Mounting the same source to the same mount point is a no-op.
+func (a DbfsAPI) MountADLSGen2(clusterID, mountName, containerName, storageAccount string,
+	clientID, clientSecretScope, clientSecretKey, tenantID string) error {
+	m := AzureADLSGen2Mount{
+		ContainerName:      containerName,
+		StorageAccountName: storageAccount,
+		ClientID:           clientID,
+		TenantID:           tenantID,
+		SecretScope:        clientSecretScope,
+		SecretKey:          clientSecretKey,
+	}
+	log.Printf("[INFO] Mounting %s to /mnt/%s with %s service principal", m.Source(), mountName, clientID)
+	mp := NewMountPoint(a.client.CommandExecutor(a.context), mountName, clusterID)
+	_, err := mp.Mount(m, a.client)
+	if err != nil {
+		return fmt.Errorf("cannot mount %s: %w", m.Source(), err)
+	}
+	return nil
+}
+
 // ResourceAzureAdlsGen2Mount creates the resource
 func ResourceAzureAdlsGen2Mount() common.Resource {
 	return deprecatedMountResource(commonMountResource(AzureADLSGen2Mount{}, map[string]*schema.Schema{
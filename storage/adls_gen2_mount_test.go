@@ -1,6 +1,10 @@
 package storage
 
 import (
+	"bytes"
+	"context"
+	"log"
+	"os"
 	"strings"
 	"testing"
 
@@ -56,3 +60,28 @@ func TestResourceAdlsGen2Mount_Create(t *testing.T) {
 	assert.Equal(t, "this_mount", d.Id())
 	assert.Equal(t, "abfss://e@test-adls-gen2.dfs.core.windows.net", d.Get("source"))
 }
+
+func TestDbfsAPIMountADLSGen2(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{})
+	require.NoError(t, err)
+	defer server.Close()
+	client.WithCommandMock(func(commandStr string) common.CommandResults {
+		trunc := commands.TrimLeadingWhitespace(commandStr)
+		assert.Contains(t, trunc, `safe_mount("/mnt/data", "abfss://c@acc.dfs.core.windows.net"`)
+		assert.Contains(t, trunc, `"fs.azure.account.oauth2.client.id":"sp"`)
+		assert.Contains(t, trunc, `"fs.azure.account.oauth2.client.secret":dbutils.secrets.get("scope", "key")`)
+		assert.Contains(t, trunc, `/tenant/oauth2/token`)
+		return common.CommandResults{
+			ResultType: "text",
+			Data:       "abfss://c@acc.dfs.core.windows.net",
+		}
+	})
+	err = NewDbfsAPI(context.Background(), client).MountADLSGen2("abc", "data",
+		"c", "acc", "sp", "scope", "key", "tenant")
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "Mounting abfss://c@acc.dfs.core.windows.net to /mnt/data")
+	assert.NotContains(t, logs.String(), "secrets")
+}
@@ -3,9 +3,14 @@ package storage
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"log"
+	"unicode/utf8"
 
+	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/common"
 )
 
@@ -70,7 +75,7 @@ func (a DbfsAPI) Create(path string, contents []byte, overwrite bool) (err error
 	}()
 	buffer := bytes.NewBuffer(contents)
 	for {
-		byteChunk := buffer.Next(1e6)
+		byteChunk := buffer.Next(maxBlockRawSize)
 		if len(byteChunk) == 0 {
 			break
 		}
@@ -78,11 +83,52 @@ func (a DbfsAPI) Create(path string, contents []byte, overwrite bool) (err error
 		err = a.addBlock(b64Data, handle)
 		if err != nil {
 			err = fmt.Errorf("cannot add block: %w", err)
+			return
 		}
 	}
 	return
 }
 
+// maxChecksumSize limits the size of existing files that UploadIfChanged downloads to compare checksums.
+// Larger files are always uploaded, as reading them back takes about as long as the upload itself.
+const maxChecksumSize = 64 * 1024 * 1024
+
+// UploadIfChanged uploads the contents of r to the path, unless a file of the same size and with the same
+// SHA-256 checksum is already there. DBFS doesn't expose checksums, so they are computed from the contents
+// of the existing file, if it's not larger than maxChecksumSize. Returns true if the file was uploaded.
+func (a DbfsAPI) UploadIfChanged(path string, r io.ReadSeeker, overwrite bool) (changed bool, err error) {
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	contents, err := io.ReadAll(r)
+	if err != nil {
+		return false, err
+	}
+	size := int64(len(contents))
+	exists, isDir, existingSize, err := a.Exists(path)
+	if err != nil {
+		return false, err
+	}
+	if exists && !isDir && existingSize == size && size <= maxChecksumSize {
+		existing, err := a.Read(path)
+		if err != nil {
+			return false, err
+		}
+		if sha256.Sum256(existing) == sha256.Sum256(contents) {
+			log.Printf("[DEBUG] Skipping upload of unchanged %s", path)
+			return false, nil
+		}
+	}
+	err = a.Create(path, contents, overwrite)
+	return err == nil, err
+}
+
+// maxBlockSize is the maximum size of base64 encoded data in a single add-block request
+const maxBlockSize = 1024 * 1024
+
+// maxBlockRawSize is the largest chunk of raw bytes that fits into maxBlockSize after base64 encoding
+const maxBlockRawSize = maxBlockSize / 4 * 3
+
 func (a DbfsAPI) createHandle(path string, overwrite bool) (int64, error) {
 	var h handleResponse
 	err := a.client.Post(a.context, "/dbfs/create", createHandle{path, overwrite}, &h)
@@ -90,6 +136,10 @@ func (a DbfsAPI) createHandle(path string, overwrite bool) (int64, error) {
 }
 
 func (a DbfsAPI) addBlock(data string, handle int64) error {
+	if len(data) > maxBlockSize {
+		return fmt.Errorf("block of %d bytes exceeds the maximum of %d bytes of base64 encoded data",
+			len(data), maxBlockSize)
+	}
 	return a.client.Post(a.context, "/dbfs/add-block", addBlock{data, handle}, nil)
 }
 
@@ -193,6 +243,32 @@ func (a DbfsAPI) readString(path string, offset, length int64) (int64, string, e
 	return readBytes.BytesRead, readBytes.Data, err
 }
 
+// maxReadStringSize limits the size of files read with ReadString
+const maxReadStringSize = 10 * 1024 * 1024
+
+// ReadString returns the contents of a UTF-8 encoded text file
+func (a DbfsAPI) ReadString(path string) (string, error) {
+	f, err := a.Status(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	if f.IsDir {
+		return "", fmt.Errorf("cannot read %s: it is a directory", path)
+	}
+	if f.FileSize > maxReadStringSize {
+		return "", fmt.Errorf("cannot read %s: file is %d bytes, which is more than %d bytes allowed for text reads",
+			path, f.FileSize, maxReadStringSize)
+	}
+	content, err := a.Read(path)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(content) {
+		return "", fmt.Errorf("cannot read %s: content is not valid UTF-8, use Read to get raw bytes", path)
+	}
+	return string(content), nil
+}
+
 // Status returns the status of a file in DBFS
 func (a DbfsAPI) Status(path string) (f FileInfo, err error) {
 	err = a.client.Get(a.context, "/dbfs/get-status", map[string]any{
@@ -200,3 +276,15 @@ func (a DbfsAPI) Status(path string) (f FileInfo, err error) {
 	}, &f)
 	return
 }
+
+// Exists checks if the path exists on DBFS and returns whether it is a directory and its size
+func (a DbfsAPI) Exists(path string) (exists bool, isDir bool, size int64, err error) {
+	f, err := a.Status(path)
+	if apierr.IsMissing(err) {
+		return false, false, 0, nil
+	}
+	if err != nil {
+		return false, false, 0, err
+	}
+	return true, f.IsDir, f.FileSize, nil
+}
@@ -2,12 +2,15 @@ package storage
 
 import (
 	"context"
+	"encoding/base64"
+	"strings"
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateFileFails(t *testing.T) {
@@ -88,6 +91,66 @@ func TestCreateFile_CloseFails(t *testing.T) {
 	})
 }
 
+func TestCreateFile_ChunksAtBlockBoundary(t *testing.T) {
+	contents := make([]byte, maxBlockRawSize+1)
+	for i := range contents {
+		contents[i] = byte(i % 251)
+	}
+	first := base64.StdEncoding.EncodeToString(contents[:maxBlockRawSize])
+	assert.Len(t, first, maxBlockSize)
+	last := base64.StdEncoding.EncodeToString(contents[maxBlockRawSize:])
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			ExpectedRequest: createHandle{
+				Path:      "/boundary",
+				Overwrite: true,
+			},
+			Response: handleResponse{123},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: addBlock{
+				Data:   first,
+				Handle: 123,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: addBlock{
+				Data:   last,
+				Handle: 123,
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/dbfs/close",
+			ExpectedRequest: handleResponse{123},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewDbfsAPI(ctx, client)
+		err := a.Create("/boundary", contents, true)
+		assert.NoError(t, err)
+
+		firstChunk, err := base64.StdEncoding.DecodeString(first)
+		assert.NoError(t, err)
+		lastChunk, err := base64.StdEncoding.DecodeString(last)
+		assert.NoError(t, err)
+		assert.Equal(t, contents, append(firstChunk, lastChunk...))
+	})
+}
+
+func TestAddBlock_TooLarge(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewDbfsAPI(ctx, client)
+		err := a.addBlock(strings.Repeat("A", maxBlockSize+4), 123)
+		assert.EqualError(t, err, "block of 1048580 bytes exceeds the maximum of 1048576 bytes of base64 encoded data")
+	})
+}
+
 func TestDbfsListRecursiveFails(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
@@ -139,3 +202,207 @@ func TestDbfsReadFails(t *testing.T) {
 		assert.EqualError(t, err, "cannot read abc: fails")
 	})
 }
+
+func TestDbfsExists(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Ffile",
+			Response: FileInfo{
+				Path:     "/file",
+				FileSize: 1024,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fdir",
+			Response: FileInfo{
+				Path:  "/dir",
+				IsDir: true,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fmissing",
+			Status:   404,
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "No file or directory exists on path /missing.",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewDbfsAPI(ctx, client)
+		exists, isDir, size, err := a.Exists("/file")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.False(t, isDir)
+		assert.Equal(t, int64(1024), size)
+
+		exists, isDir, _, err = a.Exists("/dir")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.True(t, isDir)
+
+		exists, _, _, err = a.Exists("/missing")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestDbfsReadString(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fconf.txt",
+			Response: FileInfo{
+				Path:     "/conf.txt",
+				FileSize: 8,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/read?length=1000000&path=%2Fconf.txt",
+			Response: ReadResponse{
+				BytesRead: 8,
+				Data:      base64.StdEncoding.EncodeToString([]byte("a=ünï\n")),
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fbinary",
+			Response: FileInfo{
+				Path:     "/binary",
+				FileSize: 3,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/read?length=1000000&path=%2Fbinary",
+			Response: ReadResponse{
+				BytesRead: 3,
+				Data:      base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe, 0xfd}),
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fhuge.txt",
+			Response: FileInfo{
+				Path:     "/huge.txt",
+				FileSize: 20 * 1024 * 1024,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewDbfsAPI(ctx, client)
+		content, err := a.ReadString("/conf.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, "a=ünï\n", content)
+
+		_, err = a.ReadString("/binary")
+		assert.EqualError(t, err, "cannot read /binary: content is not valid UTF-8, use Read to get raw bytes")
+
+		_, err = a.ReadString("/huge.txt")
+		assert.EqualError(t, err, "cannot read /huge.txt: file is 20971520 bytes, which is more than 10485760 bytes allowed for text reads")
+	})
+}
+
+func TestDbfsUploadIfChanged(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fsame.jar",
+			Response: FileInfo{
+				Path:     "/same.jar",
+				FileSize: 3,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/read?length=1000000&path=%2Fsame.jar",
+			Response: ReadResponse{
+				BytesRead: 3,
+				Data:      base64.StdEncoding.EncodeToString([]byte("abc")),
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fchanged.jar",
+			Response: FileInfo{
+				Path:     "/changed.jar",
+				FileSize: 3,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/read?length=1000000&path=%2Fchanged.jar",
+			Response: ReadResponse{
+				BytesRead: 3,
+				Data:      base64.StdEncoding.EncodeToString([]byte("abd")),
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			ExpectedRequest: createHandle{
+				Path:      "/changed.jar",
+				Overwrite: true,
+			},
+			Response: handleResponse{123},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: addBlock{
+				Data:   base64.StdEncoding.EncodeToString([]byte("abc")),
+				Handle: 123,
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/dbfs/close",
+			ExpectedRequest: handleResponse{123},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fresized.jar",
+			Response: FileInfo{
+				Path:     "/resized.jar",
+				FileSize: 10,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/create",
+			ExpectedRequest: createHandle{
+				Path:      "/resized.jar",
+				Overwrite: true,
+			},
+			Response: handleResponse{234},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: addBlock{
+				Data:   base64.StdEncoding.EncodeToString([]byte("abc")),
+				Handle: 234,
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/dbfs/close",
+			ExpectedRequest: handleResponse{234},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewDbfsAPI(ctx, client)
+		changed, err := a.UploadIfChanged("/same.jar", strings.NewReader("abc"), true)
+		require.NoError(t, err)
+		assert.False(t, changed)
+
+		changed, err = a.UploadIfChanged("/changed.jar", strings.NewReader("abc"), true)
+		require.NoError(t, err)
+		assert.True(t, changed)
+
+		changed, err = a.UploadIfChanged("/resized.jar", strings.NewReader("abc"), true)
+		require.NoError(t, err)
+		assert.True(t, changed)
+	})
+}
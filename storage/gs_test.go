@@ -39,7 +39,7 @@ func TestCreateOrValidateClusterForGoogleStorage_WorksOnDeletedCluster(t *testin
 		},
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/list",
+			Resource: "/api/2.1/clusters/list",
 			Response: clusters.ClusterList{
 				Clusters: []clusters.ClusterInfo{},
 			},
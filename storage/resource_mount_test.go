@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/aws"
 	"github.com/databricks/terraform-provider-databricks/clusters"
 	"github.com/databricks/terraform-provider-databricks/commands"
 	"github.com/databricks/terraform-provider-databricks/common"
@@ -242,12 +243,12 @@ func TestResourceAwsS3MountGenericCreate_WithInstanceProfile(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list",
 				Response: map[string]any{},
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list",
 				Response: clusters.ClusterList{
 					Clusters: []clusters.ClusterInfo{
 						{
@@ -281,6 +282,18 @@ func TestResourceAwsS3MountGenericCreate_WithInstanceProfile(t *testing.T) {
 				ReuseRequest: true,
 				Response:     nodeListResponse,
 			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/instance-profiles/list",
+				ReuseRequest: true,
+				Response: aws.InstanceProfileList{
+					InstanceProfiles: []aws.InstanceProfileInfo{
+						{
+							InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/s3-access",
+						},
+					},
+				},
+			},
 			{
 				Method:       "POST",
 				Resource:     "/api/2.0/clusters/create",
@@ -1453,12 +1466,12 @@ func TestResourceGcsMountGenericCreate_WithServiceAccount(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list",
 				Response: map[string]any{},
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list",
 				Response: clusters.ClusterList{
 					Clusters: []clusters.ClusterInfo{
 						{
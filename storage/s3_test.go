@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/terraform-provider-databricks/aws"
 	"github.com/databricks/terraform-provider-databricks/clusters"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
@@ -39,7 +40,7 @@ func TestPreprocessS3MountOnDeletedClusterWorks(t *testing.T) {
 		},
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/list",
+			Resource: "/api/2.1/clusters/list",
 			Response: clusters.ClusterList{
 				Clusters: []clusters.ClusterInfo{},
 			},
@@ -54,6 +55,18 @@ func TestPreprocessS3MountOnDeletedClusterWorks(t *testing.T) {
 			Method:       "GET",
 			Resource:     "/api/2.1/clusters/list-node-types",
 		},
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/instance-profiles/list",
+			ReuseRequest: true,
+			Response: aws.InstanceProfileList{
+				InstanceProfiles: []aws.InstanceProfileInfo{
+					{
+						InstanceProfileArn: "arn:aws:iam::1234567:instance-profile/s3-access",
+					},
+				},
+			},
+		},
 		{
 			Method:   "POST",
 			Resource: "/api/2.0/clusters/create",
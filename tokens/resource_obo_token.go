@@ -3,6 +3,7 @@ package tokens
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -37,6 +38,34 @@ func (a TokenManagementAPI) Read(tokenID string) (ti TokenResponse, err error) {
 	return
 }
 
+// List returns metadata of all tokens in the workspace
+func (a TokenManagementAPI) List() ([]TokenInfo, error) {
+	var tokenList TokenList
+	err := a.client.Get(a.context, "/token-management/tokens", nil, &tokenList)
+	return tokenList.TokenInfos, err
+}
+
+// RevokeExpiringBefore deletes all tokens that expire before the given time. Tokens without
+// expiration are never revoked. Returns IDs of revoked tokens.
+func (a TokenManagementAPI) RevokeExpiringBefore(t time.Time) (revoked []string, err error) {
+	tokens, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+	cutoff := t.UnixMilli()
+	for _, ti := range tokens {
+		if ti.ExpiryTime <= 0 || ti.ExpiryTime >= cutoff {
+			continue
+		}
+		err = a.Delete(ti.TokenID)
+		if err != nil {
+			return revoked, fmt.Errorf("cannot revoke token %s: %w", ti.TokenID, err)
+		}
+		revoked = append(revoked, ti.TokenID)
+	}
+	return revoked, nil
+}
+
 func ResourceOboToken() common.Resource {
 	oboTokenSchema := common.StructToSchema(OboToken{},
 		func(m map[string]*schema.Schema) map[string]*schema.Schema {
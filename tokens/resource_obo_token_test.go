@@ -1,12 +1,16 @@
 package tokens
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceOboTokenRead(t *testing.T) {
@@ -163,3 +167,45 @@ func TestResourceOboTokenCreateNoLifetimeOrComment(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "bcd", d.Id(), "Id should not be empty")
 }
+
+func TestTokenManagementRevokeExpiringBefore(t *testing.T) {
+	cutoff := time.UnixMilli(1700000000000)
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/token-management/tokens",
+			Response: TokenList{
+				TokenInfos: []TokenInfo{
+					{
+						TokenID:    "expired",
+						ExpiryTime: 1600000000000,
+					},
+					{
+						TokenID:    "soon",
+						ExpiryTime: 1699999999999,
+					},
+					{
+						TokenID:    "later",
+						ExpiryTime: 1800000000000,
+					},
+					{
+						TokenID:    "forever",
+						ExpiryTime: -1,
+					},
+				},
+			},
+		},
+		{
+			Method:   "DELETE",
+			Resource: "/api/2.0/token-management/tokens/expired?",
+		},
+		{
+			Method:   "DELETE",
+			Resource: "/api/2.0/token-management/tokens/soon?",
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		revoked, err := NewTokenManagementAPI(ctx, client).RevokeExpiringBefore(cutoff)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"expired", "soon"}, revoked)
+	})
+}
@@ -3,10 +3,12 @@ package tokens
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/workspace"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -38,13 +40,25 @@ type TokenList struct {
 
 // NewTokensAPI creates TokensAPI instance from provider meta
 func NewTokensAPI(ctx context.Context, m any) TokensAPI {
-	return TokensAPI{m.(*common.DatabricksClient), ctx}
+	return TokensAPI{
+		client:  m.(*common.DatabricksClient),
+		context: ctx,
+	}
 }
 
 // TokensAPI exposes the Secrets API
 type TokensAPI struct {
 	client  *common.DatabricksClient
 	context context.Context
+
+	checkMaxLifetime bool
+}
+
+// WithMaxLifetimeCheck returns the API that verifies requested token lifetime against
+// the maxTokenLifetimeDays workspace configuration before creating a token
+func (a TokensAPI) WithMaxLifetimeCheck() TokensAPI {
+	a.checkMaxLifetime = true
+	return a
 }
 
 // Create creates a api token given a expiration duration and a comment
@@ -57,11 +71,33 @@ func (a TokensAPI) Create(tokenLifetime time.Duration, comment string) (r TokenR
 	if comment != "" {
 		request.Comment = comment
 	}
-
+	if a.checkMaxLifetime && seconds > 0 {
+		err = a.validateLifetime(tokenLifetime)
+		if err != nil {
+			return
+		}
+	}
 	err = a.client.Post(a.context, "/token/create", request, &r)
 	return
 }
 
+func (a TokensAPI) validateLifetime(tokenLifetime time.Duration) error {
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return err
+	}
+	maxDays, err := workspace.GetMaxTokenLifetimeDays(a.context, w)
+	if err != nil {
+		return err
+	}
+	maxLifetime := time.Duration(maxDays) * 24 * time.Hour
+	if maxDays > 0 && tokenLifetime > maxLifetime {
+		return fmt.Errorf("token lifetime of %s exceeds the maximum of %d days allowed in this workspace",
+			tokenLifetime, maxDays)
+	}
+	return nil
+}
+
 // List will list all the token metadata and not the content of the tokens in the workspace
 func (a TokensAPI) List() ([]TokenInfo, error) {
 	var tokenListResult TokenList
@@ -69,6 +105,45 @@ func (a TokensAPI) List() ([]TokenInfo, error) {
 	return tokenListResult.TokenInfos, err
 }
 
+// TokenSelfInfo describes the token used by the provider itself
+type TokenSelfInfo struct {
+	TokenID    string `json:"token_id"`
+	Comment    string `json:"comment,omitempty"`
+	ExpiryTime int64  `json:"expiry_time,omitempty"`
+}
+
+// Self returns metadata of the personal access token the client authenticates with. Token listing API
+// doesn't reveal token values, so the token is resolved only when it's the only token of the calling user.
+// Otherwise common.NotSupportedError is returned.
+func (a TokensAPI) Self() (TokenSelfInfo, error) {
+	req, _ := http.NewRequestWithContext(a.context, "GET", "/", nil)
+	err := a.client.Config.Authenticate(req)
+	if err != nil {
+		return TokenSelfInfo{}, err
+	}
+	if a.client.Config.AuthType != "pat" {
+		return TokenSelfInfo{}, common.NotSupportedError{
+			Feature: "calling token information",
+			Reason:  fmt.Sprintf("client authenticates with %s instead of personal access token", a.client.Config.AuthType),
+		}
+	}
+	tokens, err := a.List()
+	if err != nil {
+		return TokenSelfInfo{}, err
+	}
+	if len(tokens) != 1 {
+		return TokenSelfInfo{}, common.NotSupportedError{
+			Feature: "calling token information",
+			Reason:  fmt.Sprintf("cannot tell which of %d tokens of the current user is used", len(tokens)),
+		}
+	}
+	return TokenSelfInfo{
+		TokenID:    tokens[0].TokenID,
+		Comment:    tokens[0].Comment,
+		ExpiryTime: tokens[0].ExpiryTime,
+	}, nil
+}
+
 // Read will return the token metadata and not the content of the token
 func (a TokensAPI) Read(tokenID string) (TokenInfo, error) {
 	var tokenInfo TokenInfo
@@ -1,13 +1,16 @@
 package tokens
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceTokenRead(t *testing.T) {
@@ -253,3 +256,80 @@ func TestResourceTokenDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestTokensSelf(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/token/list",
+			Response: TokenList{
+				TokenInfos: []TokenInfo{
+					{
+						TokenID:      "abc",
+						Comment:      "terraform",
+						CreationTime: 10,
+						ExpiryTime:   1800000000000,
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/token/list",
+			Response: TokenList{
+				TokenInfos: []TokenInfo{
+					{TokenID: "abc"},
+					{TokenID: "bcd"},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewTokensAPI(ctx, client)
+		self, err := a.Self()
+		require.NoError(t, err)
+		assert.Equal(t, TokenSelfInfo{
+			TokenID:    "abc",
+			Comment:    "terraform",
+			ExpiryTime: 1800000000000,
+		}, self)
+
+		_, err = a.Self()
+		var nse common.NotSupportedError
+		assert.ErrorAs(t, err, &nse)
+		assert.EqualError(t, err, "calling token information is not supported: cannot tell which of 2 tokens of the current user is used")
+	})
+}
+
+func TestTokensCreateWithMaxLifetimeCheck(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/workspace-conf?keys=maxTokenLifetimeDays",
+			ReuseRequest: true,
+			Response: map[string]any{
+				"maxTokenLifetimeDays": "30",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/token/create",
+			ExpectedRequest: TokenRequest{
+				LifetimeSeconds: 86400,
+			},
+			Response: TokenResponse{
+				TokenValue: "dapi...",
+				TokenInfo: &TokenInfo{
+					TokenID: "abc",
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewTokensAPI(ctx, client).WithMaxLifetimeCheck()
+		_, err := a.Create(31*24*time.Hour, "")
+		assert.EqualError(t, err, "token lifetime of 744h0m0s exceeds the maximum of 30 days allowed in this workspace")
+
+		r, err := a.Create(24*time.Hour, "")
+		require.NoError(t, err)
+		assert.Equal(t, "abc", r.TokenInfo.TokenID)
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDataSourceNotebook(t *testing.T) {
@@ -58,7 +59,7 @@ func TestDataSourceNotebook_ErrorExport(t *testing.T) {
 }
 
 func TestDataSourceNotebook_ErrorStatus(t *testing.T) {
-	qa.ResourceFixture{
+	_, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   "GET",
@@ -89,5 +90,7 @@ func TestDataSourceNotebook_ErrorStatus(t *testing.T) {
 			"path":   "/a/b/c",
 			"format": "SOURCE",
 		},
-	}.ExpectError(t, "Unauthorized")
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "authentication failed using method=pat for host=")
+	assert.ErrorContains(t, err, "Unauthorized")
 }
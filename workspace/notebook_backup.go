@@ -0,0 +1,116 @@
+package workspace
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+)
+
+// BackupToDBC exports the workspace subtree at rootPath as a DBC archive and writes it to w. When the workspace
+// refuses to export the subtree because it's too large, every subfolder is exported on its own and the results
+// are merged into a single archive.
+func (a NotebooksAPI) BackupToDBC(rootPath string, w io.Writer) error {
+	content, err := a.exportDBC(rootPath)
+	if err == nil {
+		_, err = w.Write(content)
+		return err
+	}
+	if !isExportTooLarge(err) {
+		return err
+	}
+	log.Printf("[INFO] %s is too large for a single DBC export, exporting it per subfolder", rootPath)
+	archive := zip.NewWriter(w)
+	err = a.backupChildrenToDBC(archive, rootPath, path.Base(rootPath))
+	if err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// backupChildrenToDBC adds every child of the folder to the archive under the folder's prefix
+func (a NotebooksAPI) backupChildrenToDBC(archive *zip.Writer, folderPath, prefix string) error {
+	children, err := a.ListInternalImpl(folderPath)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		childPrefix := path.Join(prefix, path.Base(child.Path))
+		content, err := a.exportDBC(child.Path)
+		if isExportTooLarge(err) && child.ObjectType == Directory {
+			err = a.backupChildrenToDBC(archive, child.Path, childPrefix)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if isExportTooLarge(err) {
+			return fmt.Errorf("%s is too large to be exported as DBC archive: %w. "+
+				"Export it separately in SOURCE format or split it into smaller notebooks", child.Path, err)
+		}
+		if err != nil {
+			return err
+		}
+		err = copyDBCEntries(archive, content, path.Dir(childPrefix))
+		if err != nil {
+			return fmt.Errorf("cannot add %s to DBC archive: %w", child.Path, err)
+		}
+	}
+	return nil
+}
+
+func (a NotebooksAPI) exportDBC(objectPath string) ([]byte, error) {
+	content, err := a.Export(objectPath, "DBC")
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(content)
+}
+
+// copyDBCEntries copies all entries of the DBC archive into the target archive, nested in the parent folder
+func copyDBCEntries(archive *zip.Writer, content []byte, parent string) error {
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return err
+	}
+	for _, entry := range r.File {
+		name := path.Join(parent, entry.Name)
+		if strings.HasSuffix(entry.Name, "/") {
+			name += "/"
+		}
+		src, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		dst, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   entry.Method,
+			Modified: entry.Modified,
+		})
+		if err == nil {
+			_, err = io.Copy(dst, src)
+		}
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isExportTooLarge returns true if the workspace refused the export because of its size
+func isExportTooLarge(err error) bool {
+	var apiErr *apierr.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.ErrorCode == "MAX_NOTEBOOK_SIZE_EXCEEDED" ||
+		strings.Contains(strings.ToLower(apiErr.Message), "too large")
+}
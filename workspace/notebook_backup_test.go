@@ -0,0 +1,155 @@
+package workspace
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDBC(t *testing.T, entries map[string]string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range entries {
+		f, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return buf.Bytes()
+}
+
+func testDBCEntries(t *testing.T, content []byte) map[string]string {
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	entries := map[string]string{}
+	for _, f := range r.File {
+		src, err := f.Open()
+		require.NoError(t, err)
+		raw, err := io.ReadAll(src)
+		require.NoError(t, err)
+		entries[f.Name] = string(raw)
+	}
+	return entries
+}
+
+var exportTooLarge = common.APIErrorBody{
+	ErrorCode: "MAX_NOTEBOOK_SIZE_EXCEEDED",
+	Message:   "Export is too large",
+}
+
+func TestNotebooksAPIBackupToDBC(t *testing.T) {
+	dbc := testDBC(t, map[string]string{
+		"foo/etl.python": "print(1)",
+	})
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=DBC&path=%2Ffoo",
+			Response: ExportPath{
+				Content: base64.StdEncoding.EncodeToString(dbc),
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		var buf bytes.Buffer
+		err := NewNotebooksAPI(ctx, client).BackupToDBC("/foo", &buf)
+		require.NoError(t, err)
+		assert.Equal(t, dbc, buf.Bytes())
+	})
+}
+
+func TestNotebooksAPIBackupToDBC_PerSubfolder(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=DBC&path=%2Ffoo",
+			Response: exportTooLarge,
+			Status:   400,
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/list?path=%2Ffoo",
+			Response: ObjectList{
+				Objects: []ObjectStatus{
+					{
+						ObjectType: Directory,
+						Path:       "/foo/bar",
+					},
+					{
+						ObjectType: Notebook,
+						Path:       "/foo/etl",
+					},
+				},
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=DBC&path=%2Ffoo%2Fbar",
+			Response: ExportPath{
+				Content: base64.StdEncoding.EncodeToString(testDBC(t, map[string]string{
+					"bar/report.sql": "SELECT 1",
+				})),
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=DBC&path=%2Ffoo%2Fetl",
+			Response: ExportPath{
+				Content: base64.StdEncoding.EncodeToString(testDBC(t, map[string]string{
+					"etl.python": "print(1)",
+				})),
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		var buf bytes.Buffer
+		err := NewNotebooksAPI(ctx, client).BackupToDBC("/foo", &buf)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"foo/bar/report.sql": "SELECT 1",
+			"foo/etl.python":     "print(1)",
+		}, testDBCEntries(t, buf.Bytes()))
+	})
+}
+
+func TestNotebooksAPIBackupToDBC_NotebookTooLarge(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=DBC&path=%2Ffoo",
+			Response: exportTooLarge,
+			Status:   400,
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/list?path=%2Ffoo",
+			Response: ObjectList{
+				Objects: []ObjectStatus{
+					{
+						ObjectType: Notebook,
+						Path:       "/foo/huge",
+					},
+				},
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=DBC&path=%2Ffoo%2Fhuge",
+			Response: exportTooLarge,
+			Status:   400,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		var buf bytes.Buffer
+		err := NewNotebooksAPI(ctx, client).BackupToDBC("/foo", &buf)
+		assert.EqualError(t, err, "/foo/huge is too large to be exported as DBC archive: Export is too large. "+
+			"Export it separately in SOURCE format or split it into smaller notebooks")
+	})
+}
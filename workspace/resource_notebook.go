@@ -1,13 +1,24 @@
 package workspace
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/common"
 
@@ -72,8 +83,22 @@ type ImportPath struct {
 	Language  string `json:"language,omitempty"`
 	Format    string `json:"format,omitempty"`
 	Overwrite bool   `json:"overwrite,omitempty"`
+	// Gzipped is true if Content is base64 encoded gzip-compressed source, which is decompressed before import
+	Gzipped bool `json:"-"`
 }
 
+// ConflictStrategy defines what CreateWithStrategy does when the target path already exists
+type ConflictStrategy string
+
+const (
+	// ConflictSkip keeps the existing object and doesn't import anything
+	ConflictSkip ConflictStrategy = "SKIP"
+	// ConflictOverwrite replaces the existing object
+	ConflictOverwrite ConflictStrategy = "OVERWRITE"
+	// ConflictRename imports to the first free path with a numeric suffix, like /foo/etl_1
+	ConflictRename ConflictStrategy = "RENAME"
+)
+
 // DeletePath contains the payload to delete a notebook
 type DeletePath struct {
 	Path      string `json:"path,omitempty"`
@@ -97,8 +122,62 @@ type NotebooksAPI struct {
 // is removed from the notebooks resource. Then we will switch to TF resource retry.
 var mtx = &sync.Mutex{}
 
+// DetectNotebookFormat sniffs the notebook content and returns DBC for zip archives, JUPYTER for JSON
+// documents with cells, or SOURCE for sources starting with the Databricks notebook magic comment
+func DetectNotebookFormat(content []byte) (string, error) {
+	if bytes.HasPrefix(content, []byte("PK\x03\x04")) {
+		return "DBC", nil
+	}
+	trimmed := bytes.TrimSpace(content)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var notebook struct {
+			Cells json.RawMessage `json:"cells"`
+		}
+		if json.Unmarshal(trimmed, &notebook) == nil && notebook.Cells != nil {
+			return Jupyter, nil
+		}
+	}
+	firstLine, _, _ := bytes.Cut(trimmed, []byte("\n"))
+	for _, comment := range []string{"#", "//", "--"} {
+		if string(bytes.TrimSpace(firstLine)) == comment+" Databricks notebook source" {
+			return "SOURCE", nil
+		}
+	}
+	return "", fmt.Errorf("cannot detect notebook format")
+}
+
+// maxWorkspacePathLength is the longest path of a workspace object, in characters
+const maxWorkspacePathLength = 4096
+
+// ValidateWorkspacePath returns an error naming the constraint, if the workspace would reject the path
+func ValidateWorkspacePath(p string) error {
+	if length := utf8.RuneCountInString(p); length > maxWorkspacePathLength {
+		return fmt.Errorf("workspace path is %d characters long, but the maximum is %d: %.64s...",
+			length, maxWorkspacePathLength, p)
+	}
+	for i, r := range p {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("workspace path %q has a control character %U at position %d, "+
+				"which is not allowed", p, r, i)
+		}
+	}
+	return nil
+}
+
 // Create creates a notebook given the content and path
 func (a NotebooksAPI) Create(r ImportPath) error {
+	if err := ValidateWorkspacePath(r.Path); err != nil {
+		return err
+	}
+	if r.Gzipped {
+		content, err := gunzipBase64(r.Content)
+		if err != nil {
+			return fmt.Errorf("cannot decompress %s: %w", r.Path, err)
+		}
+		r.Content = content
+		r.Gzipped = false
+	}
+	warnOnFormatMismatch(r)
 	if r.Format == "DBC" {
 		mtx.Lock()
 		defer mtx.Unlock()
@@ -106,6 +185,98 @@ func (a NotebooksAPI) Create(r ImportPath) error {
 	return a.client.Post(a.context, "/workspace/import", r, nil)
 }
 
+// gunzipBase64 decompresses base64 encoded gzip data and returns it base64 encoded
+func gunzipBase64(content string) (string, error) {
+	compressed, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return "", err
+	}
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// warnOnFormatMismatch logs a warning if the content looks like a different format than the requested one,
+// as importing it would produce a broken notebook
+func warnOnFormatMismatch(r ImportPath) {
+	if r.Format == "" || r.Format == Auto {
+		return
+	}
+	content, err := base64.StdEncoding.DecodeString(r.Content)
+	if err != nil {
+		return
+	}
+	detected, err := DetectNotebookFormat(content)
+	if err != nil || detected == r.Format {
+		return
+	}
+	log.Printf("[WARN] %s is imported in %s format, but its content looks like %s", r.Path, r.Format, detected)
+}
+
+// CreateFromTemplate renders the templateContent with vars using text/template syntax and imports
+// the result as a notebook. Every variable referenced by the template must be present in vars.
+func (a NotebooksAPI) CreateFromTemplate(path, templateContent string, vars map[string]string,
+	language, format string, overwrite bool) error {
+	tmpl, err := template.New(path).Option("missingkey=error").Parse(templateContent)
+	if err != nil {
+		return fmt.Errorf("cannot parse template for %s: %w", path, err)
+	}
+	var rendered strings.Builder
+	err = tmpl.Execute(&rendered, vars)
+	if err != nil {
+		return fmt.Errorf("cannot render template for %s: %w", path, err)
+	}
+	return a.Create(ImportPath{
+		Content:   base64.StdEncoding.EncodeToString([]byte(rendered.String())),
+		Path:      path,
+		Language:  language,
+		Format:    format,
+		Overwrite: overwrite,
+	})
+}
+
+// CreateWithStrategy imports base64 encoded content to the path, resolving conflicts with an existing
+// object according to the strategy. It returns the path the notebook is available at.
+func (a NotebooksAPI) CreateWithStrategy(path, content, language, format string,
+	strategy ConflictStrategy) (string, error) {
+	finalPath := path
+	switch strategy {
+	case ConflictOverwrite:
+		// import overwrites existing object on its own
+	case ConflictSkip, ConflictRename:
+		for i := 1; ; i++ {
+			_, err := a.Read(finalPath)
+			if apierr.IsMissing(err) {
+				break
+			}
+			if err != nil {
+				return "", err
+			}
+			if strategy == ConflictSkip {
+				log.Printf("[INFO] %s already exists, skipping import", path)
+				return path, nil
+			}
+			finalPath = fmt.Sprintf("%s_%d", path, i)
+		}
+	default:
+		return "", fmt.Errorf("unknown conflict strategy: %s", strategy)
+	}
+	return finalPath, a.Create(ImportPath{
+		Content:   content,
+		Path:      finalPath,
+		Language:  language,
+		Format:    format,
+		Overwrite: strategy == ConflictOverwrite,
+	})
+}
+
 // Read returns the notebook metadata and not the contents
 func (a NotebooksAPI) Read(path string) (ObjectStatus, error) {
 	var notebookInfo ObjectStatus
@@ -118,13 +289,122 @@ func (a NotebooksAPI) Read(path string) (ObjectStatus, error) {
 	return notebookInfo, err
 }
 
+// MissingObjectType is the object type that ReadMany reports for paths that don't exist
+const MissingObjectType = "MISSING"
+
+// ReadMany reads statuses of the given paths using at most concurrency parallel requests, which still go through
+// the rate limits of the client. Paths that don't exist are returned with MissingObjectType, while all other
+// failures are returned together.
+func (a NotebooksAPI) ReadMany(paths []string, concurrency int) (map[string]ObjectStatus, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	indexes := make(chan int)
+	statuses := make([]ObjectStatus, len(paths))
+	errs := make([]error, len(paths))
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				status, err := a.Read(paths[i])
+				if apierr.IsMissing(err) {
+					status, err = ObjectStatus{Path: paths[i], ObjectType: MissingObjectType}, nil
+				}
+				if err != nil {
+					errs[i] = fmt.Errorf("cannot read %s: %w", paths[i], err)
+				}
+				statuses[i] = status
+			}
+		}()
+	}
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	result := map[string]ObjectStatus{}
+	for i, path := range paths {
+		if errs[i] == nil {
+			result[path] = statuses[i]
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// GetTags returns metadata tags of the workspace object. Workspace API doesn't expose object tags yet,
+// so for existing objects it returns common.NotSupportedError, letting callers degrade gracefully.
+func (a NotebooksAPI) GetTags(path string) (map[string]string, error) {
+	_, err := a.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	return nil, objectTagsNotSupported(path)
+}
+
+// SetTags sets metadata tags of the workspace object or returns common.NotSupportedError, see GetTags
+func (a NotebooksAPI) SetTags(path string, tags map[string]string) error {
+	_, err := a.Read(path)
+	if err != nil {
+		return err
+	}
+	return objectTagsNotSupported(path)
+}
+
+func objectTagsNotSupported(path string) error {
+	return common.NotSupportedError{
+		Feature: "workspace object tags",
+		Reason:  fmt.Sprintf("workspace API doesn't expose tags for %s", path),
+	}
+}
+
+// LanguageOf returns the language of the notebook, as reported by its workspace status, without exporting
+// the notebook content.
+func (a NotebooksAPI) LanguageOf(notebookPath string) (string, error) {
+	status, err := a.Read(notebookPath)
+	if err != nil {
+		return "", err
+	}
+	if status.ObjectType != Notebook {
+		return "", fmt.Errorf("%s is %s, not a notebook", notebookPath, status.ObjectType)
+	}
+	if status.Language == "" {
+		return "", fmt.Errorf("workspace doesn't report language of %s", notebookPath)
+	}
+	return status.Language, nil
+}
+
 type workspacePathRequest struct {
 	Format string `url:"format,omitempty"`
 	Path   string `url:"path,omitempty"`
 }
 
-// Export returns the notebook content as a base64 string
+// maxExportAttempts is how many times export is requested when the response is corrupted in transit
+const maxExportAttempts = 3
+
+// Export returns the notebook content as a base64 string. Truncated responses, that are either
+// not valid JSON or don't contain valid base64, are requested again up to maxExportAttempts times.
 func (a NotebooksAPI) Export(path string, format string) (string, error) {
+	for attempt := 1; ; attempt++ {
+		content, err := a.export(path, format)
+		if err == nil {
+			_, err = base64.StdEncoding.DecodeString(content)
+			if err == nil {
+				return content, nil
+			}
+			err = fmt.Errorf("export of %s contains invalid base64: %w", path, err)
+		} else if !isCorruptedResponse(err) {
+			return "", err
+		}
+		if attempt == maxExportAttempts {
+			return "", err
+		}
+		log.Printf("[WARN] Retrying export of %s after attempt %d: %s", path, attempt, err)
+	}
+}
+
+func (a NotebooksAPI) export(path string, format string) (string, error) {
 	var notebookContent ExportPath
 	err := a.client.Get(a.context, "/workspace/export", workspacePathRequest{
 		Format: format,
@@ -134,8 +414,32 @@ func (a NotebooksAPI) Export(path string, format string) (string, error) {
 	return notebookContent.Content, err
 }
 
+func isCorruptedResponse(err error) bool {
+	var syntaxErr *json.SyntaxError
+	return errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// ExportWithFallback exports the notebook in the requested format and, if the format isn't supported
+// for the notebook, retries in the SOURCE format. It returns the format that was actually used.
+func (a NotebooksAPI) ExportWithFallback(path string, format string) (content string, usedFormat string, err error) {
+	content, err = a.Export(path, format)
+	if err == nil || format == "SOURCE" || !isUnsupportedFormatError(err) {
+		return content, format, err
+	}
+	log.Printf("[INFO] Cannot export %s as %s, falling back to SOURCE: %s", path, format, err)
+	content, err = a.Export(path, "SOURCE")
+	return content, "SOURCE", err
+}
+
+func isUnsupportedFormatError(err error) bool {
+	return errors.Is(err, apierr.ErrBadRequest) && strings.Contains(strings.ToLower(err.Error()), "format")
+}
+
 // Mkdirs will make folders in a workspace recursively given a path
 func (a NotebooksAPI) Mkdirs(path string) error {
+	if err := ValidateWorkspacePath(path); err != nil {
+		return err
+	}
 	// This mutex will be removed when mkdirs is removed from the notebooks resource.
 	// Then we will switch to TF resource retry.
 	mtx.Lock()
@@ -203,6 +507,31 @@ func (a NotebooksAPI) Delete(path string, recursive bool) error {
 	}, nil)
 }
 
+// DeleteWithEmptyParentCleanup deletes the object and then removes its ancestor directories that became empty,
+// stopping at the first non-empty ancestor. Directories outside of root and root itself are never removed.
+func (a NotebooksAPI) DeleteWithEmptyParentCleanup(objectPath, root string) error {
+	err := a.Delete(objectPath, false)
+	if err != nil {
+		return err
+	}
+	rootPrefix := strings.TrimSuffix(root, "/") + "/"
+	for dir := path.Dir(objectPath); strings.HasPrefix(dir, rootPrefix); dir = path.Dir(dir) {
+		objects, err := a.ListInternalImpl(dir)
+		if err != nil {
+			return fmt.Errorf("cannot list %s: %w", dir, err)
+		}
+		if len(objects) > 0 {
+			return nil
+		}
+		log.Printf("[DEBUG] Removing empty directory %s", dir)
+		err = a.Delete(dir, false)
+		if err != nil {
+			return fmt.Errorf("cannot remove empty directory %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
 // ResourceNotebook manages notebooks
 func ResourceNotebook() common.Resource {
 	s := FileContentSchema(map[string]*schema.Schema{
@@ -1,13 +1,22 @@
 package workspace
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"log"
 	"net/http"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceNotebookRead(t *testing.T) {
@@ -489,3 +498,437 @@ func TestNotebookLanguageSuppressSourceDiff(t *testing.T) {
 	suppress := r.Schema["language"].DiffSuppressFunc
 	assert.True(t, suppress("language", Python, Python, d))
 }
+
+func TestNotebooksAPIExportWithFallback(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=HTML&path=%2Ffoo%2Fquery.sql",
+			Status:   400,
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message:   "Export format HTML is not supported for this notebook",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2Ffoo%2Fquery.sql",
+			Response: ExportPath{
+				Content: "U0VMRUNUIDE=",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=HTML&path=%2Ffoo%2Fnotebook.py",
+			Response: ExportPath{
+				Content: "PGh0bWw+",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewNotebooksAPI(ctx, client)
+		content, format, err := a.ExportWithFallback("/foo/query.sql", "HTML")
+		require.NoError(t, err)
+		assert.Equal(t, "SOURCE", format)
+		assert.Equal(t, "U0VMRUNUIDE=", content)
+
+		content, format, err = a.ExportWithFallback("/foo/notebook.py", "HTML")
+		require.NoError(t, err)
+		assert.Equal(t, "HTML", format)
+		assert.Equal(t, "PGh0bWw+", content)
+	})
+}
+
+func TestNotebooksAPICreateFromTemplate(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/workspace/import",
+			ExpectedRequest: ImportPath{
+				// print("prod: s3://bucket")
+				Content:   "cHJpbnQoInByb2Q6IHMzOi8vYnVja2V0Iik=",
+				Path:      "/foo/config.py",
+				Language:  Python,
+				Format:    "SOURCE",
+				Overwrite: true,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewNotebooksAPI(ctx, client)
+		err := a.CreateFromTemplate("/foo/config.py", `print("{{.env}}: {{.bucket}}")`,
+			map[string]string{"env": "prod", "bucket": "s3://bucket"}, Python, "SOURCE", true)
+		require.NoError(t, err)
+
+		err = a.CreateFromTemplate("/foo/config.py", `print("{{.env}}: {{.bucket}}")`,
+			map[string]string{"env": "prod"}, Python, "SOURCE", true)
+		assert.ErrorContains(t, err, `map has no entry for key "bucket"`)
+	})
+}
+
+func TestNotebooksAPICreateWithStrategy(t *testing.T) {
+	existing := func(path, escaped string) qa.HTTPFixture {
+		return qa.HTTPFixture{
+			Method:       http.MethodGet,
+			Resource:     "/api/2.0/workspace/get-status?path=" + escaped,
+			ReuseRequest: true,
+			Response: ObjectStatus{
+				ObjectType: Notebook,
+				Path:       path,
+			},
+		}
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		existing("/foo/etl", "%2Ffoo%2Fetl"),
+		existing("/foo/etl_1", "%2Ffoo%2Fetl_1"),
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Fetl_2",
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Path (/foo/etl_2) doesn't exist.",
+			},
+			Status: 404,
+		},
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/workspace/import",
+			ExpectedRequest: ImportPath{
+				Content:   "YWJjCg==",
+				Path:      "/foo/etl",
+				Language:  Python,
+				Format:    "SOURCE",
+				Overwrite: true,
+			},
+		},
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/workspace/import",
+			ExpectedRequest: ImportPath{
+				Content:  "YWJjCg==",
+				Path:     "/foo/etl_2",
+				Language: Python,
+				Format:   "SOURCE",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewNotebooksAPI(ctx, client)
+		finalPath, err := a.CreateWithStrategy("/foo/etl", "YWJjCg==", Python, "SOURCE", ConflictSkip)
+		require.NoError(t, err)
+		assert.Equal(t, "/foo/etl", finalPath)
+
+		finalPath, err = a.CreateWithStrategy("/foo/etl", "YWJjCg==", Python, "SOURCE", ConflictOverwrite)
+		require.NoError(t, err)
+		assert.Equal(t, "/foo/etl", finalPath)
+
+		finalPath, err = a.CreateWithStrategy("/foo/etl", "YWJjCg==", Python, "SOURCE", ConflictRename)
+		require.NoError(t, err)
+		assert.Equal(t, "/foo/etl_2", finalPath)
+
+		_, err = a.CreateWithStrategy("/foo/etl", "YWJjCg==", Python, "SOURCE", "MERGE")
+		assert.EqualError(t, err, "unknown conflict strategy: MERGE")
+	})
+}
+
+func TestNotebooksAPITagsNotSupported(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       http.MethodGet,
+			Resource:     "/api/2.0/workspace/get-status?path=%2Ffoo%2Fetl",
+			ReuseRequest: true,
+			Response: ObjectStatus{
+				ObjectID:   123,
+				ObjectType: Notebook,
+				Path:       "/foo/etl",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Fmissing",
+			Status:   404,
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Path (/foo/missing) doesn't exist.",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewNotebooksAPI(ctx, client)
+		var nse common.NotSupportedError
+		_, err := a.GetTags("/foo/etl")
+		assert.ErrorAs(t, err, &nse)
+		assert.EqualError(t, err, "workspace object tags is not supported: workspace API doesn't expose tags for /foo/etl")
+
+		err = a.SetTags("/foo/etl", map[string]string{"owner": "data-eng"})
+		assert.ErrorAs(t, err, &nse)
+
+		_, err = a.GetTags("/foo/missing")
+		assert.True(t, apierr.IsMissing(err))
+	})
+}
+
+func TestNotebooksAPIDeleteWithEmptyParentCleanup(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/workspace/delete",
+			ExpectedRequest: DeletePath{
+				Path: "/Shared/a/b/c/etl",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/list?path=%2FShared%2Fa%2Fb%2Fc",
+			Response: ObjectList{},
+		},
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/workspace/delete",
+			ExpectedRequest: DeletePath{
+				Path: "/Shared/a/b/c",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/list?path=%2FShared%2Fa%2Fb",
+			Response: ObjectList{},
+		},
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/workspace/delete",
+			ExpectedRequest: DeletePath{
+				Path: "/Shared/a/b",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/list?path=%2FShared%2Fa",
+			Response: ObjectList{
+				Objects: []ObjectStatus{
+					{
+						ObjectType: Notebook,
+						Path:       "/Shared/a/report",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewNotebooksAPI(ctx, client).DeleteWithEmptyParentCleanup("/Shared/a/b/c/etl", "/Shared")
+		require.NoError(t, err)
+	})
+}
+
+func TestNotebooksAPIExportRetriesCorruptedContent(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2Ffoo%2Fetl",
+			Response: `{"content": "cHJpbnQo`,
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2Ffoo%2Fetl",
+			Response: ExportPath{
+				Content: "cHJpbnQoMS",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2Ffoo%2Fetl",
+			Response: ExportPath{
+				Content: "cHJpbnQoMSk=",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		content, err := NewNotebooksAPI(ctx, client).Export("/foo/etl", "SOURCE")
+		require.NoError(t, err)
+		assert.Equal(t, "cHJpbnQoMSk=", content)
+	})
+}
+
+func TestNotebooksAPILanguageOf(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Fetl",
+			Response: ObjectStatus{
+				ObjectType: Notebook,
+				Path:       "/foo/etl",
+				Language:   Scala,
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=%2Ffoo",
+			Response: ObjectStatus{
+				ObjectType: Directory,
+				Path:       "/foo",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewNotebooksAPI(ctx, client)
+		language, err := a.LanguageOf("/foo/etl")
+		require.NoError(t, err)
+		assert.Equal(t, Scala, language)
+
+		_, err = a.LanguageOf("/foo")
+		assert.EqualError(t, err, "/foo is DIRECTORY, not a notebook")
+	})
+}
+
+func TestDetectNotebookFormat(t *testing.T) {
+	for name, tc := range map[string]struct {
+		content string
+		format  string
+	}{
+		"dbc":          {"PK\x03\x04\x14\x00\x08\x00", "DBC"},
+		"jupyter":      {`{"cells": [], "metadata": {}, "nbformat": 4}`, "JUPYTER"},
+		"python":       {"# Databricks notebook source\nprint(1)", "SOURCE"},
+		"scala":        {"// Databricks notebook source\nprintln(1)", "SOURCE"},
+		"sql":          {"-- Databricks notebook source\nSELECT 1", "SOURCE"},
+		"leading_line": {"\n# Databricks notebook source\r\nprint(1)", "SOURCE"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			format, err := DetectNotebookFormat([]byte(tc.content))
+			require.NoError(t, err)
+			assert.Equal(t, tc.format, format)
+		})
+	}
+	_, err := DetectNotebookFormat([]byte(`{"metadata": {}}`))
+	assert.EqualError(t, err, "cannot detect notebook format")
+	_, err = DetectNotebookFormat([]byte("print(1)"))
+	assert.EqualError(t, err, "cannot detect notebook format")
+}
+
+func TestNotebooksAPICreate_WarnsOnFormatMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	jupyter := base64.StdEncoding.EncodeToString([]byte(`{"cells": []}`))
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/import",
+			ExpectedRequest: ImportPath{
+				Content: jupyter,
+				Path:    "/foo/etl",
+				Format:  "SOURCE",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewNotebooksAPI(ctx, client).Create(ImportPath{
+			Content: jupyter,
+			Path:    "/foo/etl",
+			Format:  "SOURCE",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "[WARN] /foo/etl is imported in SOURCE format, but its content looks like JUPYTER")
+	})
+}
+
+func TestValidateWorkspacePath(t *testing.T) {
+	assert.NoError(t, ValidateWorkspacePath("/Users/me@example.com/ETL notebook (v2)"))
+
+	long := "/" + strings.Repeat("a", maxWorkspacePathLength)
+	assert.EqualError(t, ValidateWorkspacePath(long), "workspace path is 4097 characters long, "+
+		"but the maximum is 4096: "+long[:64]+"...")
+
+	assert.EqualError(t, ValidateWorkspacePath("/foo/etl\nnotebook"), `workspace path "/foo/etl\nnotebook" `+
+		"has a control character U+000A at position 8, which is not allowed")
+}
+
+func TestNotebooksAPICreate_InvalidPath(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		notebooksAPI := NewNotebooksAPI(ctx, client)
+		err := notebooksAPI.Create(ImportPath{
+			Path:    "/foo/\tetl",
+			Content: "YWJjCg==",
+		})
+		assert.ErrorContains(t, err, "has a control character U+0009")
+
+		err = notebooksAPI.Mkdirs("/" + strings.Repeat("a", maxWorkspacePathLength))
+		assert.ErrorContains(t, err, "but the maximum is 4096")
+	})
+}
+
+func TestNotebooksAPICreate_Gzipped(t *testing.T) {
+	source := []byte("# Databricks notebook source\nprint(1)\n")
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	_, err := w.Write(source)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/import",
+			ExpectedRequest: ImportPath{
+				Content:  base64.StdEncoding.EncodeToString(source),
+				Path:     "/foo/etl",
+				Language: Python,
+				Format:   "SOURCE",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		notebooksAPI := NewNotebooksAPI(ctx, client)
+		err := notebooksAPI.Create(ImportPath{
+			Content:  base64.StdEncoding.EncodeToString(compressed.Bytes()),
+			Path:     "/foo/etl",
+			Language: Python,
+			Format:   "SOURCE",
+			Gzipped:  true,
+		})
+		require.NoError(t, err)
+
+		err = notebooksAPI.Create(ImportPath{
+			Content: base64.StdEncoding.EncodeToString(source),
+			Path:    "/foo/etl",
+			Gzipped: true,
+		})
+		assert.EqualError(t, err, "cannot decompress /foo/etl: gzip: invalid header")
+	})
+}
+
+func TestNotebooksAPIReadMany(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Fa",
+			Response: ObjectStatus{
+				ObjectID:   1,
+				ObjectType: Notebook,
+				Path:       "/foo/a",
+				Language:   Python,
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Fb",
+			Response: ObjectStatus{
+				ObjectID:   2,
+				ObjectType: Directory,
+				Path:       "/foo/b",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Fmissing",
+			Status:   404,
+			Response: apierr.NotFound("Path (/foo/missing) doesn't exist."),
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=%2Ffoo%2Fbroken",
+			Status:   400,
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message:   "Invalid path",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		statuses, err := NewNotebooksAPI(ctx, client).ReadMany(
+			[]string{"/foo/a", "/foo/b", "/foo/missing", "/foo/broken"}, 2)
+		assert.EqualError(t, err, "cannot read /foo/broken: Invalid path")
+		assert.Len(t, statuses, 3)
+		assert.Equal(t, int64(1), statuses["/foo/a"].ObjectID)
+		assert.Equal(t, Directory, statuses["/foo/b"].ObjectType)
+		assert.Equal(t, MissingObjectType, statuses["/foo/missing"].ObjectType)
+	})
+}
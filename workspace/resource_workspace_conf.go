@@ -15,6 +15,7 @@ import (
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/internal/docs"
 
+	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/settings"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -136,6 +137,80 @@ func deleteWorkspaceConf(ctx context.Context, d *schema.ResourceData, c *common.
 	return nil
 }
 
+// getWorkspaceConfValue returns the value of the key and true, if it's set. Keys that are missing from
+// the response or have empty or null values are not set.
+func getWorkspaceConfValue(ctx context.Context, w *databricks.WorkspaceClient, key string) (string, bool, error) {
+	conf, err := w.WorkspaceConf.GetStatus(ctx, settings.GetStatusRequest{
+		Keys: key,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	if conf == nil || (*conf)[key] == "" {
+		return "", false, nil
+	}
+	return (*conf)[key], true, nil
+}
+
+// GetWorkspaceConfWithDefault returns the workspace configuration value for the key and true, if the key is
+// explicitly set. Keys that are missing from the response or have empty values are reported as not set,
+// with the default value.
+func GetWorkspaceConfWithDefault(ctx context.Context, w *databricks.WorkspaceClient,
+	key, def string) (string, bool, error) {
+	value, set, err := getWorkspaceConfValue(ctx, w, key)
+	if err != nil {
+		return "", false, err
+	}
+	if !set {
+		return def, false, nil
+	}
+	return value, true, nil
+}
+
+// GetWorkspaceConfBool returns the workspace configuration value for the key as boolean, or false if it's not set
+func GetWorkspaceConfBool(ctx context.Context, w *databricks.WorkspaceClient, key string) (bool, error) {
+	value, set, err := getWorkspaceConfValue(ctx, w, key)
+	if err != nil || !set {
+		return false, err
+	}
+	b, err := strconv.ParseBool(strings.ToLower(value))
+	if err != nil {
+		return false, fmt.Errorf("workspace conf %s has non-boolean value %q", key, value)
+	}
+	return b, nil
+}
+
+// GetWorkspaceConfInt returns the workspace configuration value for the key as integer, or zero if it's not set
+func GetWorkspaceConfInt(ctx context.Context, w *databricks.WorkspaceClient, key string) (int, error) {
+	value, set, err := getWorkspaceConfValue(ctx, w, key)
+	if err != nil || !set {
+		return 0, err
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("workspace conf %s has non-integer value %q", key, value)
+	}
+	return i, nil
+}
+
+// MaxTokenLifetimeDaysKey is the workspace configuration key capping the lifetime of new tokens
+const MaxTokenLifetimeDaysKey = "maxTokenLifetimeDays"
+
+// GetMaxTokenLifetimeDays returns the maximum lifetime of new tokens in days, or zero if no limit is configured
+func GetMaxTokenLifetimeDays(ctx context.Context, w *databricks.WorkspaceClient) (int, error) {
+	return GetWorkspaceConfInt(ctx, w, MaxTokenLifetimeDaysKey)
+}
+
+// SetMaxTokenLifetimeDays limits the lifetime of new tokens to the given number of days
+func SetMaxTokenLifetimeDays(ctx context.Context, w *databricks.WorkspaceClient, days int) error {
+	if days <= 0 {
+		return fmt.Errorf("max token lifetime must be positive, got %d days", days)
+	}
+	return w.WorkspaceConf.SetStatus(ctx, settings.WorkspaceConf{
+		MaxTokenLifetimeDaysKey: strconv.Itoa(days),
+	})
+}
+
 // ResourceWorkspaceConf maintains workspace configuration for specified keys
 func ResourceWorkspaceConf() common.Resource {
 	return common.Resource{
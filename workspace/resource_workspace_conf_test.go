@@ -1,12 +1,14 @@
 package workspace
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWorkspaceConfCreate(t *testing.T) {
@@ -336,3 +338,144 @@ func TestWorkspaceConfUpdateOnInvalidConf(t *testing.T) {
 		"some-valid-conf": "bar",
 	}, config)
 }
+
+func TestGetWorkspaceConfTyped(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=enableTokensConfig",
+			Response: map[string]any{
+				"enableTokensConfig": "TRUE",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=maxTokenLifetimeDays",
+			Response: map[string]any{
+				"maxTokenLifetimeDays": "90",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=maxTokenLifetimeDays",
+			Response: map[string]any{
+				"maxTokenLifetimeDays": "ninety",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=enableTokensConfig",
+			Response: map[string]any{
+				"enableTokensConfig": nil,
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=maxTokenLifetimeDays",
+			Response: map[string]any{
+				"maxTokenLifetimeDays": "",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		enabled, err := GetWorkspaceConfBool(ctx, w, "enableTokensConfig")
+		require.NoError(t, err)
+		assert.True(t, enabled)
+
+		days, err := GetWorkspaceConfInt(ctx, w, "maxTokenLifetimeDays")
+		require.NoError(t, err)
+		assert.Equal(t, 90, days)
+
+		_, err = GetWorkspaceConfInt(ctx, w, "maxTokenLifetimeDays")
+		assert.EqualError(t, err, `workspace conf maxTokenLifetimeDays has non-integer value "ninety"`)
+
+		enabled, err = GetWorkspaceConfBool(ctx, w, "enableTokensConfig")
+		require.NoError(t, err)
+		assert.False(t, enabled)
+
+		days, err = GetWorkspaceConfInt(ctx, w, "maxTokenLifetimeDays")
+		require.NoError(t, err)
+		assert.Equal(t, 0, days)
+	})
+}
+
+func TestGetWorkspaceConfWithDefault(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=enableIpAccessLists",
+			Response: map[string]any{
+				"enableIpAccessLists": "false",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=enableIpAccessLists",
+			Response: map[string]any{
+				"enableIpAccessLists": nil,
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=enableIpAccessLists",
+			Response: map[string]any{},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		value, set, err := GetWorkspaceConfWithDefault(ctx, w, "enableIpAccessLists", "true")
+		require.NoError(t, err)
+		assert.True(t, set)
+		assert.Equal(t, "false", value)
+
+		for i := 0; i < 2; i++ {
+			value, set, err = GetWorkspaceConfWithDefault(ctx, w, "enableIpAccessLists", "true")
+			require.NoError(t, err)
+			assert.False(t, set)
+			assert.Equal(t, "true", value)
+		}
+	})
+}
+
+func TestMaxTokenLifetimeDays(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodPatch,
+			Resource: "/api/2.0/workspace-conf",
+			ExpectedRequest: map[string]string{
+				"maxTokenLifetimeDays": "90",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=maxTokenLifetimeDays",
+			Response: map[string]any{
+				"maxTokenLifetimeDays": "90",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=maxTokenLifetimeDays",
+			Response: map[string]any{
+				"maxTokenLifetimeDays": "",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		w, err := client.WorkspaceClient()
+		require.NoError(t, err)
+		err = SetMaxTokenLifetimeDays(ctx, w, 90)
+		require.NoError(t, err)
+
+		days, err := GetMaxTokenLifetimeDays(ctx, w)
+		require.NoError(t, err)
+		assert.Equal(t, 90, days)
+
+		days, err = GetMaxTokenLifetimeDays(ctx, w)
+		require.NoError(t, err)
+		assert.Equal(t, 0, days)
+
+		err = SetMaxTokenLifetimeDays(ctx, w, 0)
+		assert.EqualError(t, err, "max token lifetime must be positive, got 0 days")
+	})
+}